@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/adapter/search"
+
+	"github.com/spf13/cobra"
+)
+
+var reindexCmd = &cobra.Command{
+	Use: "reindex",
+	Short: "rebuild the search index",
+	Long: `rebuild the bleve search index at SEARCH_INDEX_PATH from the database.
+The index can only be opened by one process, stop the server first.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.NewConfig()
+		db, err := cfg.ConnectionPostgres()
+		if err != nil {
+			log.Fatalf("Error connecting to database: %v", err)
+		}
+
+		searchService, err := search.NewBleveSearch(cfg, repository.NewContentRepository(db.DB), repository.NewCategoryRepository(db.DB))
+		if err != nil {
+			log.Fatalf("Error opening search index: %v", err)
+		}
+		defer searchService.Close()
+
+		total, err := searchService.Reindex(context.Background())
+		if err != nil {
+			log.Fatalf("Error reindexing after %d contents: %v", total, err)
+		}
+
+		log.Printf("indexed %d contents", total)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reindexCmd)
+}
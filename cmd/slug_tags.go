@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/adapter/search"
+	"trustnews/internal/core/service"
+	"trustnews/lib/slug"
+
+	"github.com/spf13/cobra"
+)
+
+var slugTagsCmd = &cobra.Command{
+	Use: "slug-tags",
+	Short: "slug the tags migrated from the old tags column",
+	Long: `give every tag the slug the application makes of its name. Tags ending up
+with the same slug are merged into the most used one, tags whose name leaves no
+slug get a "tag-N" fallback. The server does this at startup while tags still
+hold the placeholder slug of the tags migration, run it by hand to slug the tags
+without starting the server, then rebuild the search index with reindex.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.NewConfig()
+		db, err := cfg.ConnectionPostgres()
+		if err != nil {
+			log.Fatalf("Error connecting to database: %v", err)
+		}
+
+		contentRepo := repository.NewContentRepository(db.DB)
+		categoryRepo := repository.NewCategoryRepository(db.DB)
+		tagRepo := repository.NewTagRepository(db.DB)
+		slugifier := slug.New(cfg.App.SlugMaxLength, cfg.App.SlugStopWords)
+		// the bleve index may be held by the server, it is rebuilt with reindex
+		searchService := search.NewPostgresSearch(contentRepo, categoryRepo, tagRepo)
+		tagService := service.NewTagService(tagRepo, contentRepo, service.NewAuditService(repository.NewAuditLogRepository(db.DB)), slugifier, searchService)
+
+		total, merged, fallbacks, err := tagService.SlugTags(context.Background())
+		if err != nil {
+			log.Fatalf("Error slugging tags: %v", err)
+		}
+
+		log.Printf("slugged %d tags, %d merged, %d without a slug of their own", total, merged, fallbacks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(slugTagsCmd)
+}
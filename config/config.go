@@ -1,13 +1,48 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type App struct {
 	AppPort string `json:"app_port"`
 	AppEnv string `json:"app_env"`
+	ProxyHeader string `json:"proxy_header"`
 
 	JwtSecretKey string `json:"jwt_secret_key"`
 	JwtIssuer string `json:"jwt_issuer"`
+	JwtAccessTokenTTL time.Duration `json:"jwt_access_token_ttl"`
+	JwtRefreshTokenTTL time.Duration `json:"jwt_refresh_token_ttl"`
+	JwtSigningMethod string `json:"jwt_signing_method"`
+	JwtKeyID string `json:"jwt_key_id"`
+	JwtPrivateKeyPath string `json:"jwt_private_key_path"`
+	JwtVerifyKeys string `json:"jwt_verify_keys"`
+
+	FrontendUrl string `json:"frontend_url"`
+	SiteName string `json:"site_name"`
+	PasswordResetTTL time.Duration `json:"password_reset_ttl"`
+	PasswordRateLimit int `json:"password_rate_limit"`
+	PasswordRateWindow time.Duration `json:"password_rate_window"`
+	TotpIssuer string `json:"totp_issuer"`
+
+	LoginMaxAttempts int `json:"login_max_attempts"`
+	LoginIpMaxAttempts int `json:"login_ip_max_attempts"`
+	LoginAttemptWindow time.Duration `json:"login_attempt_window"`
+	LoginBackoffBase time.Duration `json:"login_backoff_base"`
+	LoginLockoutDuration time.Duration `json:"login_lockout_duration"`
+
+	SchedulerInterval time.Duration `json:"scheduler_interval"`
+
+	ReportRateLimit int `json:"report_rate_limit"`
+	ReportRateWindow time.Duration `json:"report_rate_window"`
+
+	SlugMaxLength int `json:"slug_max_length"`
+	SlugStopWords []string `json:"slug_stop_words"`
+
+	CursorSecretKey string `json:"cursor_secret_key"`
 }
 
 type PsqlDB struct {
@@ -29,21 +64,95 @@ type CloudflareR2 struct {
 	PublicUrl string `json:"public_url"`
 }
 
+type Mail struct {
+	Driver string `json:"driver"`
+	Host string `json:"host"`
+	Port string `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From string `json:"from"`
+	LogPath string `json:"log_path"`
+}
+
+type Search struct {
+	Driver string `json:"driver"`
+	IndexPath string `json:"index_path"`
+	Fuzziness int `json:"fuzziness"`
+	FacetSize int `json:"facet_size"`
+	SuggestCacheTTL time.Duration `json:"suggest_cache_ttl"`
+}
+
 type Config struct {
 	App App
 	Psql PsqlDB
 	R2 CloudflareR2
+	Mail Mail
+	Search Search
 }
 
 // Berfungsi untuk mengambil dan setup value yg ada di file env ke dalam struct
 func NewConfig() *Config {
+	viper.SetDefault("JWT_ACCESS_TOKEN_TTL", "15m")
+	viper.SetDefault("JWT_REFRESH_TOKEN_TTL", "168h")
+	viper.SetDefault("PASSWORD_RESET_TTL", "1h")
+	viper.SetDefault("PASSWORD_RATE_LIMIT", 5)
+	viper.SetDefault("PASSWORD_RATE_WINDOW", "15m")
+	viper.SetDefault("MAIL_DRIVER", "log")
+	viper.SetDefault("TOTP_ISSUER", "Trust News")
+	viper.SetDefault("APP_SITE_NAME", "Trust News")
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_IP_MAX_ATTEMPTS", 20)
+	viper.SetDefault("LOGIN_ATTEMPT_WINDOW", "15m")
+	viper.SetDefault("LOGIN_BACKOFF_BASE", "1s")
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("SCHEDULER_INTERVAL", "30s")
+	viper.SetDefault("REPORT_RATE_LIMIT", 5)
+	viper.SetDefault("REPORT_RATE_WINDOW", "10m")
+	viper.SetDefault("SLUG_MAX_LENGTH", 80)
+	viper.SetDefault("SEARCH_DRIVER", "postgres")
+	viper.SetDefault("SEARCH_INDEX_PATH", "./temp/search.bleve")
+	viper.SetDefault("SEARCH_FUZZINESS", 1)
+	viper.SetDefault("SEARCH_FACET_SIZE", 10)
+	viper.SetDefault("SEARCH_SUGGEST_CACHE_TTL", "30s")
+	viper.SetDefault("CURSOR_SECRET_KEY", viper.GetString("JWT_SECRET_KEY"))
+
 	return &Config{
 		App: App{
 			AppPort: viper.GetString("APP_PORT"),
 			AppEnv: viper.GetString("APP_ENV"),
+			ProxyHeader: viper.GetString("APP_PROXY_HEADER"),
 
 			JwtSecretKey: viper.GetString("JWT_SECRET_KEY"),
 			JwtIssuer: viper.GetString("JWT_ISSUER"),
+			JwtAccessTokenTTL: viper.GetDuration("JWT_ACCESS_TOKEN_TTL"),
+			JwtRefreshTokenTTL: viper.GetDuration("JWT_REFRESH_TOKEN_TTL"),
+			JwtSigningMethod: viper.GetString("JWT_SIGNING_METHOD"),
+			JwtKeyID: viper.GetString("JWT_KEY_ID"),
+			JwtPrivateKeyPath: viper.GetString("JWT_PRIVATE_KEY_PATH"),
+			JwtVerifyKeys: viper.GetString("JWT_VERIFY_KEYS"),
+
+			FrontendUrl: viper.GetString("APP_FRONTEND_URL"),
+			SiteName: viper.GetString("APP_SITE_NAME"),
+			PasswordResetTTL: viper.GetDuration("PASSWORD_RESET_TTL"),
+			PasswordRateLimit: viper.GetInt("PASSWORD_RATE_LIMIT"),
+			PasswordRateWindow: viper.GetDuration("PASSWORD_RATE_WINDOW"),
+			TotpIssuer: viper.GetString("TOTP_ISSUER"),
+
+			LoginMaxAttempts: viper.GetInt("LOGIN_MAX_ATTEMPTS"),
+			LoginIpMaxAttempts: viper.GetInt("LOGIN_IP_MAX_ATTEMPTS"),
+			LoginAttemptWindow: viper.GetDuration("LOGIN_ATTEMPT_WINDOW"),
+			LoginBackoffBase: viper.GetDuration("LOGIN_BACKOFF_BASE"),
+			LoginLockoutDuration: viper.GetDuration("LOGIN_LOCKOUT_DURATION"),
+
+			SchedulerInterval: viper.GetDuration("SCHEDULER_INTERVAL"),
+
+			ReportRateLimit: viper.GetInt("REPORT_RATE_LIMIT"),
+			ReportRateWindow: viper.GetDuration("REPORT_RATE_WINDOW"),
+
+			SlugMaxLength: viper.GetInt("SLUG_MAX_LENGTH"),
+			SlugStopWords: strings.Split(viper.GetString("SLUG_STOP_WORDS"), ","),
+
+			CursorSecretKey: viper.GetString("CURSOR_SECRET_KEY"),
 		},
 		Psql: PsqlDB{
 			Host: viper.GetString("DATABASE_HOST"),
@@ -62,5 +171,21 @@ func NewConfig() *Config {
 			AccountID: viper.GetString("CLOUDFLARE_R2_ACCOUNT_ID"),
 			PublicUrl: viper.GetString("CLOUDFLARE_R2_PUBLIC_URL"),
 		},
+		Mail: Mail{
+			Driver: viper.GetString("MAIL_DRIVER"),
+			Host: viper.GetString("MAIL_SMTP_HOST"),
+			Port: viper.GetString("MAIL_SMTP_PORT"),
+			Username: viper.GetString("MAIL_SMTP_USERNAME"),
+			Password: viper.GetString("MAIL_SMTP_PASSWORD"),
+			From: viper.GetString("MAIL_FROM"),
+			LogPath: viper.GetString("MAIL_LOG_PATH"),
+		},
+		Search: Search{
+			Driver: viper.GetString("SEARCH_DRIVER"),
+			IndexPath: viper.GetString("SEARCH_INDEX_PATH"),
+			Fuzziness: viper.GetInt("SEARCH_FUZZINESS"),
+			FacetSize: viper.GetInt("SEARCH_FACET_SIZE"),
+			SuggestCacheTTL: viper.GetDuration("SEARCH_SUGGEST_CACHE_TTL"),
+		},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE "users" ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'admin';
+ALTER TABLE "users" ALTER COLUMN role SET DEFAULT 'author';
+ALTER TABLE "users" ADD CONSTRAINT chk_users_role CHECK (role IN ('admin', 'editor', 'author', 'fact_checker'));
+
+CREATE INDEX idx_users_role ON users(role);
@@ -0,0 +1,2 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE "users" DROP COLUMN IF EXISTS is_active;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE "users" ADD COLUMN deactivated_at TIMESTAMP NULL;
+
+CREATE INDEX idx_users_is_active ON users(is_active);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS "login_throttles" (
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('ip', 'account')),
+    identifier VARCHAR(255) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    PRIMARY KEY (scope, identifier)
+);
+
+CREATE TABLE IF NOT EXISTS "lockout_events" (
+    id SERIAL PRIMARY KEY,
+    scope VARCHAR(10) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    user_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    failures INT NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_lockout_events_created_at ON lockout_events(created_at);
+CREATE INDEX idx_lockout_events_user_id ON lockout_events(user_id);
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    api_key_id INT NULL REFERENCES api_keys(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
\ No newline at end of file
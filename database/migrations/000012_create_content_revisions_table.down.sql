@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "content_revisions";
\ No newline at end of file
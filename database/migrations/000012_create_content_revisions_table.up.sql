@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS "content_revisions" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    revision_number INT NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    excerpt VARCHAR(250) NOT NULL,
+    description TEXT NOT NULL,
+    image TEXT NULL,
+    tags TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    is_valid VARCHAR(20) NOT NULL,
+    category_id INT NULL,
+    edited_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    restored_from INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (content_id, revision_number)
+);
+
+-- existing contents start their history with their current state
+INSERT INTO content_revisions (content_id, revision_number, title, excerpt, description, image, tags, status, is_valid, category_id, edited_by_id, created_at)
+SELECT id, 1, title, excerpt, description, image, tags, status, is_valid, category_id, created_by_id, COALESCE(updated_at, created_at)
+FROM contents;
\ No newline at end of file
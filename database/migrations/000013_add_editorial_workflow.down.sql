@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "content_reviews";
+
+DROP INDEX IF EXISTS idx_contents_status;
+ALTER TABLE contents DROP CONSTRAINT IF EXISTS contents_status_check;
+ALTER TABLE contents ALTER COLUMN status SET DEFAULT 'PUBLISH';
+
+UPDATE contents SET status = 'PUBLISH' WHERE status = 'PUBLISHED';
+UPDATE content_revisions SET status = 'PUBLISH' WHERE status = 'PUBLISHED';
\ No newline at end of file
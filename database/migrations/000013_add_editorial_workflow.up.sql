@@ -0,0 +1,22 @@
+UPDATE contents SET status = 'PUBLISHED' WHERE status = 'PUBLISH';
+UPDATE contents SET status = 'DRAFT' WHERE status NOT IN ('DRAFT', 'IN_REVIEW', 'APPROVED', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED');
+UPDATE content_revisions SET status = 'PUBLISHED' WHERE status = 'PUBLISH';
+
+ALTER TABLE contents ALTER COLUMN status SET DEFAULT 'DRAFT';
+ALTER TABLE contents ADD CONSTRAINT contents_status_check
+    CHECK (status IN ('DRAFT', 'IN_REVIEW', 'APPROVED', 'SCHEDULED', 'PUBLISHED', 'ARCHIVED'));
+
+CREATE INDEX idx_contents_status ON contents(status);
+
+CREATE TABLE IF NOT EXISTS "content_reviews" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    reviewer_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_reviews_content_id ON content_reviews(content_id);
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE contents ADD COLUMN publish_at TIMESTAMP NULL;
+ALTER TABLE contents ADD COLUMN unpublish_at TIMESTAMP NULL;
+
+-- the scheduler only ever looks at these rows
+CREATE INDEX idx_contents_publish_at ON contents(publish_at) WHERE status = 'SCHEDULED';
+CREATE INDEX idx_contents_unpublish_at ON contents(unpublish_at) WHERE status = 'PUBLISHED';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "fact_checks";
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "fact_checks" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL UNIQUE REFERENCES contents(id) ON DELETE CASCADE,
+    claim TEXT NOT NULL,
+    claimant VARCHAR(200) NOT NULL,
+    rating VARCHAR(20) NOT NULL CHECK (rating IN ('TRUE', 'MOSTLY_TRUE', 'MISLEADING', 'FALSE', 'SATIRE')),
+    reviewer_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP NOT NULL,
+    evidence_links JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_fact_checks_rating ON fact_checks(rating);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "content_sources";
\ No newline at end of file
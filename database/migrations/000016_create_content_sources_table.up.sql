@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "content_sources" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    publisher VARCHAR(200) NOT NULL DEFAULT '',
+    accessed_at TIMESTAMP NULL,
+    archive_url TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_sources_content_id ON content_sources(content_id);
+CREATE INDEX idx_content_sources_domain ON content_sources(domain);
\ No newline at end of file
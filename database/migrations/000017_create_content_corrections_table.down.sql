@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "content_corrections";
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "content_corrections" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    corrected_at TIMESTAMP NOT NULL,
+    what_was_wrong TEXT NOT NULL,
+    what_changed TEXT NOT NULL,
+    severity VARCHAR(20) NOT NULL CHECK (severity IN ('MINOR', 'MAJOR', 'CRITICAL')),
+    created_by_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_corrections_content_id ON content_corrections(content_id);
+CREATE INDEX idx_content_corrections_corrected_at ON content_corrections(corrected_at);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "content_reports";
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "content_reports" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('FACTUAL_ERROR', 'MISLEADING', 'MISSING_CONTEXT', 'OUTDATED', 'OTHER')),
+    details TEXT NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'NEW' CHECK (status IN ('NEW', 'TRIAGED', 'UPHELD', 'REJECTED')),
+    moderator_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    moderator_note TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_content_reports_status ON content_reports(status);
+CREATE INDEX idx_content_reports_content_id ON content_reports(content_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS category_slug_histories;
+DROP TABLE IF EXISTS content_slug_histories;
+DROP INDEX IF EXISTS idx_contents_slug;
+ALTER TABLE contents DROP COLUMN IF EXISTS slug;
\ No newline at end of file
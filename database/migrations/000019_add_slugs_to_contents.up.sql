@@ -0,0 +1,30 @@
+ALTER TABLE contents ADD COLUMN slug VARCHAR(255) NULL;
+
+UPDATE contents SET slug = trim(both '-' from regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'));
+UPDATE contents SET slug = 'content' WHERE slug = '';
+
+-- titles that share a slug keep it on the oldest content, the others get their id appended
+UPDATE contents c SET slug = c.slug || '-' || c.id
+WHERE EXISTS (SELECT 1 FROM contents d WHERE d.slug = c.slug AND d.id < c.id);
+
+ALTER TABLE contents ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX idx_contents_slug ON contents(slug);
+
+-- old slugs keep resolving and are answered with a permanent redirect
+CREATE TABLE IF NOT EXISTS "content_slug_histories" (
+    id SERIAL PRIMARY KEY,
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    slug VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_slug_histories_content_id ON content_slug_histories(content_id);
+
+CREATE TABLE IF NOT EXISTS "category_slug_histories" (
+    id SERIAL PRIMARY KEY,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    slug VARCHAR(200) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_category_slug_histories_category_id ON category_slug_histories(category_id);
\ No newline at end of file
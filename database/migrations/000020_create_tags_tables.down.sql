@@ -0,0 +1,11 @@
+ALTER TABLE contents ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+
+UPDATE contents c SET tags = COALESCE((
+    SELECT string_agg(t.name, ',' ORDER BY ct.position)
+    FROM content_tags ct
+    JOIN tags t ON t.id = ct.tag_id
+    WHERE ct.content_id = c.id
+), '');
+
+DROP TABLE IF EXISTS content_tags;
+DROP TABLE IF EXISTS tags;
\ No newline at end of file
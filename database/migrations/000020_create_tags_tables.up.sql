@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS "tags" (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) UNIQUE NOT NULL,
+    usage_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tags_usage_count ON tags(usage_count DESC);
+
+CREATE TABLE IF NOT EXISTS "content_tags" (
+    content_id INT NOT NULL REFERENCES contents(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (content_id, tag_id)
+);
+
+CREATE INDEX idx_content_tags_tag_id ON content_tags(tag_id);
+
+-- split the comma-joined column, empty entries are dropped and tags sharing a
+-- name in any case become one tag named after its first use. The slugs are
+-- placeholders, the server slugs the tags like the application does at startup
+-- (or run the slug-tags command)
+CREATE TEMP TABLE legacy_tags AS
+SELECT c.id AS content_id, left(trim(t.name), 100) AS name, t.position,
+    'legacy-' || md5(lower(left(trim(t.name), 100))) AS slug
+FROM contents c, unnest(string_to_array(c.tags, ',')) WITH ORDINALITY AS t(name, position);
+
+DELETE FROM legacy_tags WHERE name = '';
+
+INSERT INTO tags (name, slug)
+SELECT DISTINCT ON (slug) name, slug
+FROM legacy_tags
+ORDER BY slug, content_id, position;
+
+INSERT INTO content_tags (content_id, tag_id, position)
+SELECT DISTINCT ON (l.content_id, t.id) l.content_id, t.id, l.position - 1
+FROM legacy_tags l
+JOIN tags t ON t.slug = l.slug
+ORDER BY l.content_id, t.id, l.position;
+
+UPDATE tags SET usage_count = (SELECT COUNT(*) FROM content_tags WHERE tag_id = tags.id);
+
+DROP TABLE legacy_tags;
+
+ALTER TABLE contents DROP COLUMN tags;
\ No newline at end of file
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self,
+    DROP COLUMN IF EXISTS seo_description,
+    DROP COLUMN IF EXISTS seo_title,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS sort_order,
+    DROP COLUMN IF EXISTS parent_id;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE categories
+    ADD COLUMN parent_id INT NULL REFERENCES categories(id) ON DELETE RESTRICT,
+    ADD COLUMN sort_order INT NOT NULL DEFAULT 0,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN seo_title VARCHAR(200) NOT NULL DEFAULT '',
+    ADD COLUMN seo_description VARCHAR(320) NOT NULL DEFAULT '',
+    ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
\ No newline at end of file
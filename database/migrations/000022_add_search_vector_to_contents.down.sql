@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_contents_search_vector;
+DROP TRIGGER IF EXISTS trg_contents_search_vector ON contents;
+DROP FUNCTION IF EXISTS contents_search_vector_trigger();
+DROP FUNCTION IF EXISTS contents_search_vector(TEXT, TEXT, TEXT);
+ALTER TABLE contents DROP COLUMN IF EXISTS search_vector;
\ No newline at end of file
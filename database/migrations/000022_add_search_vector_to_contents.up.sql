@@ -0,0 +1,28 @@
+ALTER TABLE contents ADD COLUMN search_vector tsvector;
+
+-- titles weigh most, then excerpts, then the body without markup. Every part is
+-- indexed with the Indonesian and the English configuration so stems of both
+-- languages match.
+CREATE OR REPLACE FUNCTION contents_search_vector(title TEXT, excerpt TEXT, description TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('indonesian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('indonesian', coalesce(excerpt, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+        setweight(to_tsvector('indonesian', regexp_replace(coalesce(description, ''), '<[^>]*>', ' ', 'g')), 'C') ||
+        setweight(to_tsvector('english', regexp_replace(coalesce(description, ''), '<[^>]*>', ' ', 'g')), 'C')
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION contents_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := contents_search_vector(NEW.title, NEW.excerpt, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_contents_search_vector
+BEFORE INSERT OR UPDATE OF title, excerpt, description ON contents
+FOR EACH ROW EXECUTE FUNCTION contents_search_vector_trigger();
+
+UPDATE contents SET search_vector = contents_search_vector(title, excerpt, description);
+
+CREATE INDEX idx_contents_search_vector ON contents USING GIN (search_vector);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tags_name_trgm;
+DROP INDEX IF EXISTS idx_categories_title_trgm;
+DROP INDEX IF EXISTS idx_contents_title_trgm;
+ALTER TABLE contents DROP COLUMN IF EXISTS view_count;
\ No newline at end of file
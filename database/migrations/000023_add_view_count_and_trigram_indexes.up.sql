@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- bumped on every reader visit, suggestions rank popular titles first
+ALTER TABLE contents ADD COLUMN view_count BIGINT NOT NULL DEFAULT 0;
+
+-- trigram indexes serve the prefix (ILIKE) and the word similarity matches of
+-- the search suggestions
+CREATE INDEX idx_contents_title_trgm ON contents USING GIN (title gin_trgm_ops);
+CREATE INDEX idx_categories_title_trgm ON categories USING GIN (title gin_trgm_ops);
+CREATE INDEX idx_tags_name_trgm ON tags USING GIN (name gin_trgm_ops);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE contents DROP CONSTRAINT IF EXISTS contents_is_valid_check;
\ No newline at end of file
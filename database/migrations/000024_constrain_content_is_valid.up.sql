@@ -0,0 +1,9 @@
+-- is_valid follows the fact check verdict, contents without one stay VALID
+-- until a reader report is upheld
+UPDATE contents SET is_valid = 'VALID' WHERE is_valid NOT IN ('VALID', 'NEEDS_REVIEW', 'INVALID');
+UPDATE contents SET is_valid = CASE fact_checks.rating WHEN 'MISLEADING' THEN 'INVALID' WHEN 'FALSE' THEN 'INVALID' ELSE 'VALID' END
+FROM fact_checks
+WHERE fact_checks.content_id = contents.id AND contents.is_valid <> 'NEEDS_REVIEW';
+
+ALTER TABLE contents ADD CONSTRAINT contents_is_valid_check
+    CHECK (is_valid IN ('VALID', 'NEEDS_REVIEW', 'INVALID'));
\ No newline at end of file
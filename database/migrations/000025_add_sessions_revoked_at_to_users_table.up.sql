@@ -0,0 +1,3 @@
+-- access tokens issued before it are refused, a password reset sets it so the
+-- sessions of whoever had the old password end right away
+ALTER TABLE "users" ADD COLUMN sessions_revoked_at TIMESTAMP NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- emails are unique regardless of case, accounts differing only in the case of
+-- their email have to be merged before this runs
+CREATE UNIQUE INDEX idx_users_email_lower ON users (lower(email));
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "content_revisions" DROP COLUMN IF EXISTS sources;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- the sources of the content at the revision, as a JSON array. Revisions made
+-- before sources were recorded keep NULL and leave the sources alone on restore
+ALTER TABLE "content_revisions" ADD COLUMN sources JSONB NULL;
\ No newline at end of file
@@ -1,6 +1,7 @@
 package seeds
 
 import (
+	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/domain/model"
 	"trustnews/lib/conv"
 
@@ -18,6 +19,8 @@ func SeedRoles(db *gorm.DB) {
 		Name: "Admin",
 		Email: "admin@gmail.com",
 		Password: string(bytes),
+		Role: entity.RoleAdmin,
+		IsActive: true,
 	}
 
 	if err := db.FirstOrCreate(&admin, model.User{Email: "admin@gmail.com"}).Error; err != nil {
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"errors"
+	"time"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ApiKeyHandler interface {
+	GetApiKeys(c *fiber.Ctx) error
+	CreateApiKey(c *fiber.Ctx) error
+	RevokeApiKey(c *fiber.Ctx) error
+}
+
+type apiKeyHandler struct {
+	apiKeyService service.ApiKeyService
+}
+
+// GetApiKeys implements ApiKeyHandler.
+func (a *apiKeyHandler) GetApiKeys(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetApiKeys - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid page number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetApiKeys - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid limit number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.QueryString{
+		Limit:  limit,
+		Page:   page,
+		Search: c.Query("search"),
+	}
+
+	results, totalData, totalPages, err := a.apiKeyService.GetApiKeys(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] GetApiKeys - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respKeys := []response.ApiKeyResponse{}
+	for _, key := range results {
+		respKey := response.ApiKeyResponse{
+			ID:        key.ID,
+			Name:      key.Name,
+			KeyPrefix: key.KeyPrefix,
+			Scopes:    key.Scopes,
+			User:      toUserResponse(key.User),
+			CreatedAt: key.CreatedAt.Format(time.RFC3339),
+		}
+		if key.ExpiresAt != nil {
+			respKey.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+		}
+		if key.LastUsedAt != nil {
+			respKey.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+		}
+		if key.RevokedAt != nil {
+			respKey.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+		}
+
+		respKeys = append(respKeys, respKey)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respKeys
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// CreateApiKey implements ApiKeyHandler. The key acts on behalf of user_id, or
+// of the admin creating it when user_id is left out.
+func (a *apiKeyHandler) CreateApiKey(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] CreateApiKey - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	var req request.ApiKeyRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateApiKey - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateApiKey - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		code = "[HANDLER] CreateApiKey - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Expiry Must Be In The Future"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	ownerID := req.UserID
+	if ownerID == 0 {
+		ownerID = claims.UserID
+	}
+
+	key, id, err := a.apiKeyService.CreateApiKey(c.Context(), entity.ApiKeyEntity{
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		UserID:      ownerID,
+		CreatedByID: claims.UserID,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		code = "[HANDLER] CreateApiKey - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrInvalidApiKeyOwner) || errors.Is(err, service.ErrScopeNotAllowed) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "API Key Created, Store It Now, It Will Not Be Shown Again"
+	defaultSuccessReponse.Data = response.CreatedApiKeyResponse{
+		ID:  id,
+		Key: key,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.Status(fiber.StatusCreated).JSON(defaultSuccessReponse)
+}
+
+// RevokeApiKey implements ApiKeyHandler.
+func (a *apiKeyHandler) RevokeApiKey(c *fiber.Ctx) error {
+	apiKeyID, err := conv.StringToInt64(c.Params("apiKeyID"))
+	if err != nil {
+		code = "[HANDLER] RevokeApiKey - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = a.apiKeyService.RevokeApiKey(c.Context(), apiKeyID)
+	if err != nil {
+		code = "[HANDLER] RevokeApiKey - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "API Key Not Found Or Already Revoked"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "API Key Revoked"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func NewApiKeyHandler(apiKeyService service.ApiKeyService) ApiKeyHandler {
+	return &apiKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
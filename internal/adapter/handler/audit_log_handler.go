@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"time"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type AuditLogHandler interface {
+	GetAuditLogs(c *fiber.Ctx) error
+}
+
+type auditLogHandler struct {
+	auditService service.AuditService
+}
+
+// GetAuditLogs implements AuditLogHandler. Every filter is optional, from and
+// to accept RFC3339 timestamps or plain dates.
+func (a *auditLogHandler) GetAuditLogs(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetAuditLogs - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid page number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetAuditLogs - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid limit number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	query := entity.AuditLogQuery{
+		Limit:      limit,
+		Page:       page,
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+	}
+
+	if c.Query("actor_id") != "" {
+		query.ActorID, err = conv.StringToInt64(c.Query("actor_id"))
+		if err != nil {
+			code = "[HANDLER] GetAuditLogs - 3"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid actor_id"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	if c.Query("entity_id") != "" {
+		query.EntityID, err = conv.StringToInt64(c.Query("entity_id"))
+		if err != nil {
+			code = "[HANDLER] GetAuditLogs - 4"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid entity_id"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	if c.Query("from") != "" {
+		from, err := parseQueryTime(c.Query("from"), false)
+		if err != nil {
+			code = "[HANDLER] GetAuditLogs - 5"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid from date"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+		query.From = &from
+	}
+
+	if c.Query("to") != "" {
+		to, err := parseQueryTime(c.Query("to"), true)
+		if err != nil {
+			code = "[HANDLER] GetAuditLogs - 6"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid to date"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+		query.To = &to
+	}
+
+	results, totalData, totalPages, err := a.auditService.GetAuditLogs(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] GetAuditLogs - 7"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respLogs := []response.AuditLogResponse{}
+	for _, auditLog := range results {
+		respLog := response.AuditLogResponse{
+			ID:         auditLog.ID,
+			ApiKeyID:   auditLog.ApiKeyID,
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityID:   auditLog.EntityID,
+			Changes:    auditLog.Changes,
+			IPAddress:  auditLog.IPAddress,
+			UserAgent:  auditLog.UserAgent,
+			CreatedAt:  auditLog.CreatedAt.Format(time.RFC3339),
+		}
+		if auditLog.Actor != nil {
+			actor := toUserResponse(*auditLog.Actor)
+			respLog.Actor = &actor
+		}
+
+		respLogs = append(respLogs, respLog)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respLogs
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// parseQueryTime accepts an RFC3339 timestamp or a YYYY-MM-DD date. A plain
+// date used as an upper bound covers the whole day.
+func parseQueryTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
+
+func NewAuditLogHandler(auditService service.AuditService) AuditLogHandler {
+	return &auditLogHandler{
+		auditService: auditService,
+	}
+}
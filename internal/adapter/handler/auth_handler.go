@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"math"
+	"strconv"
 	"trustnews/lib/validator"
 	"trustnews/internal/adapter/handler/request"
 	"trustnews/internal/adapter/handler/response"
@@ -19,6 +22,12 @@ var validate = validator.New()
 
 type AuthHandler interface {
 	Login(c *fiber.Ctx) error
+	LoginTwoFactor(c *fiber.Ctx) error
+	RefreshToken(c *fiber.Ctx) error
+	Logout(c *fiber.Ctx) error
+	Jwks(c *fiber.Ctx) error
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
 }
 
 type authHandler struct {
@@ -50,6 +59,7 @@ func (a *authHandler) Login(c *fiber.Ctx) error {
 	reqLogin := entity.LoginRequest{
 		Email: req.Email,
 		Password: req.Password,
+		IPAddress: c.IP(),
 	}
 
 	result, err := a.authService.GetUserByEmail(c.Context(), reqLogin)
@@ -59,21 +69,288 @@ func (a *authHandler) Login(c *fiber.Ctx) error {
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
 
-		if err.Error() == "Invalid Password" {
+		if errors.Is(err, service.ErrInvalidCredentials) {
 			return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
 		}
 
+		if lockedErr := (*service.LoginLockedError)(nil); errors.As(err, &lockedErr) {
+			return tooManyLoginAttempts(c, lockedErr)
+		}
+
+		if errors.Is(err, service.ErrUserInactive) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
+	if result.MfaRequired {
+		resp.Meta.Status = true
+		resp.Meta.Message = "Two-Factor Code Required"
+		resp.MfaRequired = true
+		resp.MfaToken = result.MfaToken
+		resp.MfaExpiresAt = result.MfaExpiresAt
+
+		return c.JSON(resp)
+	}
+
 	resp.Meta.Status = true
 	resp.Meta.Message = "Login Successful"
 	resp.AccessToken = result.AccessToken
 	resp.ExpiresAt = result.ExpiresAt
+	resp.RefreshToken = result.RefreshToken
+	resp.RefreshExpiresAt = result.RefreshExpiresAt
+	resp.MfaSetupRequired = result.MfaSetupRequired
+
+	return c.JSON(resp)
+}
+
+// LoginTwoFactor is the second login step for users with 2FA enabled.
+func (a *authHandler) LoginTwoFactor(c *fiber.Ctx) error {
+	req := request.TwoFactorLoginRequest{}
+	resp := response.SuccessAuthResponse{}
+
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] LoginTwoFactor - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] LoginTwoFactor - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := a.authService.VerifyTwoFactorLogin(c.Context(), req.MfaToken, req.Code, c.IP())
+	if err != nil {
+		code = "[HANDLER] LoginTwoFactor - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if lockedErr := (*service.LoginLockedError)(nil); errors.As(err, &lockedErr) {
+			return tooManyLoginAttempts(c, lockedErr)
+		}
+
+		if errors.Is(err, service.ErrInvalidMfaToken) || errors.Is(err, service.ErrInvalidMfaCode) {
+			return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrUserInactive) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	resp.Meta.Status = true
+	resp.Meta.Message = "Login Successful"
+	resp.AccessToken = result.AccessToken
+	resp.ExpiresAt = result.ExpiresAt
+	resp.RefreshToken = result.RefreshToken
+	resp.RefreshExpiresAt = result.RefreshExpiresAt
+	resp.MfaSetupRequired = result.MfaSetupRequired
+
+	return c.JSON(resp)
+}
+
+func (a *authHandler) RefreshToken(c *fiber.Ctx) error {
+	req := request.RefreshTokenRequest{}
+	resp := response.SuccessAuthResponse{}
+
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] RefreshToken - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] RefreshToken - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := a.authService.RefreshToken(c.Context(), req.RefreshToken)
+	if err != nil {
+		code = "[HANDLER] RefreshToken - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrUserInactive) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	resp.Meta.Status = true
+	resp.Meta.Message = "Token Refreshed Successfully"
+	resp.AccessToken = result.AccessToken
+	resp.ExpiresAt = result.ExpiresAt
+	resp.RefreshToken = result.RefreshToken
+	resp.RefreshExpiresAt = result.RefreshExpiresAt
+	resp.MfaSetupRequired = result.MfaSetupRequired
 
 	return c.JSON(resp)
 }
 
+func (a *authHandler) Logout(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] Logout - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	err = a.authService.Logout(c.Context(), *claims.Claims)
+	if err != nil {
+		code = "[HANDLER] Logout - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Logout Successful"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// Jwks is served in the standard JWK Set format rather than the default
+// envelope, so JWT libraries can consume it directly.
+func (a *authHandler) Jwks(c *fiber.Ctx) error {
+	resp := response.JwksResponse{Keys: []response.JwkResponse{}}
+	for _, key := range a.authService.GetJwks(c.Context()) {
+		resp.Keys = append(resp.Keys, response.JwkResponse{
+			Kty: key.Kty,
+			Use: key.Use,
+			Alg: key.Alg,
+			Kid: key.Kid,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(resp)
+}
+
+func (a *authHandler) ForgotPassword(c *fiber.Ctx) error {
+	req := request.ForgotPasswordRequest{}
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] ForgotPassword - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] ForgotPassword - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = a.authService.ForgotPassword(c.Context(), req.Email)
+	if err != nil {
+		code = "[HANDLER] ForgotPassword - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "If The Email Is Registered, A Reset Link Has Been Sent"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func (a *authHandler) ResetPassword(c *fiber.Ctx) error {
+	req := request.ResetPasswordRequest{}
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] ResetPassword - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] ResetPassword - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = a.authService.ResetPassword(c.Context(), req.Token, req.NewPassword)
+	if err != nil {
+		code = "[HANDLER] ResetPassword - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Password Has Been Reset"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// tooManyLoginAttempts answers a locked login with 429 and a Retry-After
+// header in whole seconds.
+func tooManyLoginAttempts(c *fiber.Ctx, lockedErr *service.LoginLockedError) error {
+	retryAfter := int(math.Ceil(lockedErr.RetryAfter.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+	return c.Status(fiber.StatusTooManyRequests).JSON(errorResp)
+}
+
 func NewAuthHandler(authService service.AuthService) AuthHandler {
 	return &authHandler{
 		authService: authService,
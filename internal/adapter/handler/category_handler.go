@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"trustnews/internal/adapter/handler/request"
 	"trustnews/internal/adapter/handler/response"
 	"trustnews/internal/core/domain/entity"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
 )
 
 var defaultSuccessReponse response.DefaultSuccessReponse
@@ -30,7 +32,7 @@ type categoryHandler struct {
 
 // GetCategoryFE implements CategoryHandler.
 func (ch *categoryHandler) GetCategoryFE(c *fiber.Ctx) error {
-	results, err := ch.categoryService.GetCategories(c.Context())
+	results, err := ch.categoryService.GetCategoryTree(c.Context())
 	if err != nil {
 		code = "[HANDLER] GetCategoryFE - 1"
 		log.Errorw(code, err)
@@ -42,14 +44,7 @@ func (ch *categoryHandler) GetCategoryFE(c *fiber.Ctx) error {
 
 	categoryResponses := []response.SuccessCategoryResponse{}
 	for _, result := range results {
-		categoryResponse := response.SuccessCategoryResponse{
-			ID:            result.ID,
-			Title:         result.Title,
-			Slug:          result.Slug,
-			CreatedByName: result.User.Name,
-		}
-
-		categoryResponses = append(categoryResponses, categoryResponse)
+		categoryResponses = append(categoryResponses, toCategoryResponse(result))
 	}
 
 	defaultSuccessReponse.Meta.Status = true
@@ -62,7 +57,7 @@ func (ch *categoryHandler) GetCategoryFE(c *fiber.Ctx) error {
 
 func (ch *categoryHandler) CreateCategory(c *fiber.Ctx) error {
 	var req request.CategoryRequest
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	userID := claims.UserID
 	if userID == 0 {
 		code = "[HANDLER] CreateCategory - 1"
@@ -92,7 +87,12 @@ func (ch *categoryHandler) CreateCategory(c *fiber.Ctx) error {
 	}
 
 	reqEntity := entity.CategoryEntity{
-		Title: req.Title,
+		Title:          req.Title,
+		ParentID:       req.ParentID.Value,
+		SortOrder:      valueOr(req.SortOrder, 0),
+		Description:    valueOr(req.Description, ""),
+		SeoTitle:       valueOr(req.SeoTitle, ""),
+		SeoDescription: valueOr(req.SeoDescription, ""),
 		User: entity.UserEntity{
 			ID: int64(userID),
 		},
@@ -105,6 +105,10 @@ func (ch *categoryHandler) CreateCategory(c *fiber.Ctx) error {
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
 
+		if errors.Is(err, service.ErrParentNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
 	}
 
@@ -116,7 +120,7 @@ func (ch *categoryHandler) CreateCategory(c *fiber.Ctx) error {
 }
 
 func (ch *categoryHandler) DeleteCategory(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	userID := claims.UserID
 	if userID == 0 {
 		code = "[HANDLER] DeleteCategory - 1"
@@ -158,7 +162,7 @@ func (ch *categoryHandler) DeleteCategory(c *fiber.Ctx) error {
 
 func (ch *categoryHandler) EditCategoryByID(c *fiber.Ctx) error {
 	var req request.CategoryRequest
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	userID := claims.UserID
 	if userID == 0 {
 		code = "[HANDLER] EditCategoryByID - 1"
@@ -198,21 +202,49 @@ func (ch *categoryHandler) EditCategoryByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
+	// what was left out keeps its current value
+	current, err := ch.categoryService.GetCategoryByID(c.Context(), id)
+	if err != nil {
+		code = "[HANDLER] EditCategoryByID - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
 	reqEntity := entity.CategoryEntity{
-		ID:    id,
-		Title: req.Title,
+		ID:             id,
+		Title:          req.Title,
+		ParentID:       current.ParentID,
+		SortOrder:      valueOr(req.SortOrder, current.SortOrder),
+		Description:    valueOr(req.Description, current.Description),
+		SeoTitle:       valueOr(req.SeoTitle, current.SeoTitle),
+		SeoDescription: valueOr(req.SeoDescription, current.SeoDescription),
 		User: entity.UserEntity{
 			ID: int64(userID),
 		},
 	}
+	if req.ParentID.Set {
+		reqEntity.ParentID = req.ParentID.Value
+	}
 
 	err = ch.categoryService.EditCategoryByID(c.Context(), reqEntity)
 	if err != nil {
-		code = "[HANDLER] EditCategoryByID - 5"
+		code = "[HANDLER] EditCategoryByID - 6"
 		log.Errorw(code, err)
 		errorResp.Meta.Status = true
 		errorResp.Meta.Message = err.Error()
 
+		if errors.Is(err, service.ErrParentNotFound) || errors.Is(err, service.ErrCategoryCycle) {
+			errorResp.Meta.Status = false
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
@@ -224,7 +256,7 @@ func (ch *categoryHandler) EditCategoryByID(c *fiber.Ctx) error {
 }
 
 func (ch *categoryHandler) GetCategories(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	userID := claims.UserID
 	if userID == 0 {
 		code = "[HANDLER] GetCategories - 1"
@@ -245,14 +277,7 @@ func (ch *categoryHandler) GetCategories(c *fiber.Ctx) error {
 
 	categoryResponses := []response.SuccessCategoryResponse{}
 	for _, result := range results {
-		categoryResponse := response.SuccessCategoryResponse{
-			ID:            result.ID,
-			Title:         result.Title,
-			Slug:          result.Slug,
-			CreatedByName: result.User.Name,
-		}
-
-		categoryResponses = append(categoryResponses, categoryResponse)
+		categoryResponses = append(categoryResponses, toCategoryResponse(result))
 	}
 
 	defaultSuccessReponse.Meta.Status = true
@@ -264,7 +289,7 @@ func (ch *categoryHandler) GetCategories(c *fiber.Ctx) error {
 }
 
 func (ch *categoryHandler) GetCategoryByID(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	userID := claims.UserID
 	if userID == 0 {
 		code = "[HANDLER] GetCategoryByID - 1"
@@ -294,12 +319,7 @@ func (ch *categoryHandler) GetCategoryByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
-	categoryResponse := response.SuccessCategoryResponse{
-		ID:            id,
-		Title:         result.Title,
-		Slug:          result.Slug,
-		CreatedByName: result.User.Name,
-	}
+	categoryResponse := toCategoryResponse(*result)
 
 	defaultSuccessReponse.Meta.Status = true
 	defaultSuccessReponse.Meta.Message = "Categories Fetched Detail Successfully"
@@ -309,6 +329,34 @@ func (ch *categoryHandler) GetCategoryByID(c *fiber.Ctx) error {
 	return c.JSON(defaultSuccessReponse)
 }
 
+func toCategoryResponse(category entity.CategoryEntity) response.SuccessCategoryResponse {
+	resp := response.SuccessCategoryResponse{
+		ID:             category.ID,
+		Title:          category.Title,
+		Slug:           category.Slug,
+		ParentID:       category.ParentID,
+		SortOrder:      category.SortOrder,
+		Description:    category.Description,
+		SeoTitle:       category.SeoTitle,
+		SeoDescription: category.SeoDescription,
+		CreatedByName:  category.User.Name,
+	}
+	for _, child := range category.Children {
+		resp.Children = append(resp.Children, toCategoryResponse(child))
+	}
+
+	return resp
+}
+
 func NewCategoryHandler(categoryService service.CategoryService) CategoryHandler {
 	return &categoryHandler{categoryService: categoryService}
 }
+
+// valueOr returns what value points to, or fallback when it was left out.
+func valueOr[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+
+	return *value
+}
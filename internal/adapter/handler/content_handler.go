@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 	"trustnews/internal/adapter/handler/request"
@@ -10,10 +12,13 @@ import (
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/service"
 	"trustnews/lib/conv"
+	"trustnews/lib/pagination"
+	"trustnews/lib/sorting"
 	validatorLib "trustnews/lib/validator"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
 )
 
 type ContentHandler interface {
@@ -23,14 +28,54 @@ type ContentHandler interface {
 	UpdateContent(c *fiber.Ctx) error
 	DeleteContent(c *fiber.Ctx) error
 	UploadImageR2(c *fiber.Ctx) error
+	GetRevisions(c *fiber.Ctx) error
+	GetRevision(c *fiber.Ctx) error
+	GetRevisionDiff(c *fiber.Ctx) error
+	RestoreRevision(c *fiber.Ctx) error
+	SubmitContent(c *fiber.Ctx) error
+	ApproveContent(c *fiber.Ctx) error
+	RejectContent(c *fiber.Ctx) error
+	ScheduleContent(c *fiber.Ctx) error
+	PublishContent(c *fiber.Ctx) error
+	ArchiveContent(c *fiber.Ctx) error
+	GetContentReviews(c *fiber.Ctx) error
 
 	// FE
 	GetContentWithQuery(c *fiber.Ctx) error
 	GetContentDetail(c *fiber.Ctx) error
+	GetContentBySlug(c *fiber.Ctx) error
+	GetContentsByCategorySlug(c *fiber.Ctx) error
+	GetContentsByTagSlug(c *fiber.Ctx) error
 }
 
 type contentHandler struct {
 	contentService service.ContentService
+	categoryService service.CategoryService
+	tagService service.TagService
+	cursorKey []byte
+}
+
+// maxContentLimit caps the page size of the content lists.
+const maxContentLimit = 100
+
+// adminContentSorts and feContentSorts are the keys the content lists can be
+// sorted on, anything else is rejected before it reaches the query.
+var adminContentSorts = []string{
+	entity.ContentSortTitle,
+	entity.ContentSortStatus,
+	entity.ContentSortCreatedAt,
+	entity.ContentSortUpdatedAt,
+	entity.ContentSortPublishedAt,
+	entity.ContentSortPopularity,
+	entity.ContentSortRelevance,
+}
+
+var feContentSorts = []string{
+	entity.ContentSortPublishedAt,
+	entity.ContentSortCreatedAt,
+	entity.ContentSortTitle,
+	entity.ContentSortPopularity,
+	entity.ContentSortRelevance,
 }
 
 // GetContentDetail implements ContentHandler.
@@ -53,39 +98,148 @@ func (ch *contentHandler) GetContentDetail(c *fiber.Ctx) error {
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
 
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
+	// unpublished contents do not exist for readers
+	if result.Status != entity.ContentStatusPublished {
+		code = "[HANDLER] GetContentDetail - 3"
+		log.Errorw(code, gorm.ErrRecordNotFound)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Content Not Found"
+
+		return c.Status(fiber.StatusNotFound).JSON(errorResp)
+	}
+
+	ch.contentService.RecordView(c.Context(), result.ID)
+
 	defaultSuccessReponse.Meta.Status = true
 	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toContentDetailResponse(*result)
+	defaultSuccessReponse.Pagination = nil
 
-	respContent := response.ContentResponse{
-		ID:           result.ID,
-		Title:        result.Title,
-		Excerpt:      result.Excerpt,
-		Description:  result.Description,
-		Image:        result.Image,
-		Tags:         result.Tags,
-		Status:       result.Status,
-		IsValid: 	  result.IsValid,
-		CategoryID:   result.CategoryID,
-		CreatedByID:  result.CreatedByID,
-		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
-		CategoryName: result.Category.Title,
-		Author:       result.User.Name,
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetContentBySlug implements ContentHandler. An old slug is answered with a
+// permanent redirect to the current one.
+func (ch *contentHandler) GetContentBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	result, err := ch.contentService.GetContentBySlug(c.Context(), slug)
+	if err != nil {
+		code = "[HANDLER] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
-	defaultSuccessReponse.Data = respContent
+	if result.Status != entity.ContentStatusPublished {
+		code = "[HANDLER] GetContentBySlug - 2"
+		log.Errorw(code, gorm.ErrRecordNotFound)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Content Not Found"
+
+		return c.Status(fiber.StatusNotFound).JSON(errorResp)
+	}
+
+	if result.Slug != slug {
+		return redirectToSlug(c, result.Slug, "/api/fe/contents/slug/"+result.Slug)
+	}
+
+	ch.contentService.RecordView(c.Context(), result.ID)
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toContentDetailResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
 	return c.JSON(defaultSuccessReponse)
 }
 
 // GetContentWithQuery implements ContentHandler.
 func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
+	var categoryID int64
+	if c.Query("categoryID") != "" {
+		categoryID, err = conv.StringToInt64(c.Query("categoryID"))
+		if err != nil {
+			code := "[HANDLER] GetContentWithQuery - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid category ID"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	return ch.publishedContents(c, entity.QueryString{CategoryID: categoryID})
+}
+
+// GetContentsByCategorySlug implements ContentHandler. An old category slug is
+// answered with a permanent redirect to the current one.
+func (ch *contentHandler) GetContentsByCategorySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	category, err := ch.categoryService.GetCategoryBySlug(c.Context(), slug)
+	if err != nil {
+		code = "[HANDLER] GetContentsByCategorySlug - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Category Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	if category.Slug != slug {
+		return redirectToSlug(c, category.Slug, "/api/fe/categories/"+category.Slug+"/contents")
+	}
+
+	return ch.publishedContents(c, entity.QueryString{CategoryID: category.ID})
+}
+
+// GetContentsByTagSlug implements ContentHandler.
+func (ch *contentHandler) GetContentsByTagSlug(c *fiber.Ctx) error {
+	tag, err := ch.tagService.GetTagBySlug(c.Context(), c.Params("slug"))
+	if err != nil {
+		code = "[HANDLER] GetContentsByTagSlug - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Tag Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	return ch.publishedContents(c, entity.QueryString{TagID: tag.ID})
+}
+
+// publishedContents answers the reader facing content list, filter limits it
+// to one category or tag.
+func (ch *contentHandler) publishedContents(c *fiber.Ctx, filter entity.QueryString) error {
 	page := 1
 	if c.Query("page") != "" {
 		page, err = conv.StringToInt(c.Query("page"))
-		if err != nil {
-			code := "[HANDLER] GetContentWithQuery - 1"
+		if err != nil || page < 1 {
+			code := "[HANDLER] publishedContents - 1"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
 			errorResp.Meta.Message = "Invalid Page Number"
@@ -97,8 +251,8 @@ func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
 	limit := 6
 	if c.Query("limit") != "" {
 		limit, err = conv.StringToInt(c.Query("limit"))
-		if err != nil {
-			code := "[HANDLER] GetContentWithQuery - 2"
+		if err != nil || limit < 1 {
+			code := "[HANDLER] publishedContents - 2"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
 			errorResp.Meta.Message = "Invalid Limit Number"
@@ -106,15 +260,16 @@ func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 		}
 	}
+	limit = min(limit, maxContentLimit)
 
-	orderBy := "created_at"
-	if c.Query("orderBy") != "" {
-		orderBy = c.Query("orderBy")
-	}
+	sort, err := parseSort(c, feContentSorts, "-"+entity.ContentSortCreatedAt)
+	if err != nil {
+		code := "[HANDLER] publishedContents - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
 
-	orderType := "desc"
-	if c.Query("orderType") != "" {
-		orderType = c.Query("orderType")
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
 	search := ""
@@ -122,14 +277,14 @@ func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
 		search = c.Query("search")
 	}
 
-	categoryID := 0
-	if c.Query("categoryID") != "" {
-		categoryID, err = conv.StringToInt(c.Query("categoryID"))
+	var cursor *entity.CursorEntity
+	if c.Query("cursor") != "" {
+		cursor, err = ch.decodeCursor(c.Query("cursor"), sort, search)
 		if err != nil {
-			code := "[HANDLER] GetContentWithQuery - 3"
+			code := "[HANDLER] publishedContents - 4"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
-			errorResp.Meta.Message = "Invalid category ID"
+			errorResp.Meta.Message = "Invalid Cursor"
 
 			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 		}
@@ -138,21 +293,50 @@ func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
 	reqEntity := entity.QueryString{
 		Limit:      limit,
 		Page:       page,
-		OrderBy:    orderBy,
-		OrderType:  orderType,
+		Sort:       sort,
 		Search:     search,
-		Status:     "PUBLISH",
-		CategoryID: int64(categoryID),
+		Status:     entity.ContentStatusPublished,
+		CategoryID: filter.CategoryID,
+		TagID:      filter.TagID,
+		Cursor:     cursor,
 	}
 
-	results, totalData, totalPages, err := ch.contentService.GetContents(c.Context(), reqEntity)
-	if err != nil {
-		code := "[HANDLER] GetContentWithQuery - 4"
-		log.Errorw(code, err)
-		errorResp.Meta.Status = false
-		errorResp.Meta.Message = err.Error()
+	var results []entity.ContentEntity
+	paginationResp := &response.PaginationResponse{PerPage: limit}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	// a page number keeps older clients on offset paging, everyone else pages
+	// by cursor
+	if c.Query("page") != "" && cursor == nil {
+		var totalData, totalPages int64
+		results, totalData, totalPages, err = ch.contentService.GetContents(c.Context(), reqEntity)
+		if err != nil {
+			code := "[HANDLER] publishedContents - 5"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+		}
+
+		paginationResp.TotalRecords = int(totalData)
+		paginationResp.Page = page
+		paginationResp.TotalPages = int(totalPages)
+	} else {
+		contentPage, err := ch.contentService.GetContentsByCursor(c.Context(), reqEntity)
+		if err != nil {
+			code := "[HANDLER] publishedContents - 6"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = err.Error()
+
+			return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+		}
+
+		results = contentPage.Contents
+		paginationResp.TotalRecords = int(contentPage.TotalData)
+		paginationResp.TotalPages = int(contentPage.TotalPages)
+		paginationResp.NextCursor = ch.encodeCursor(contentPage.Next, sort)
+		paginationResp.PrevCursor = ch.encodeCursor(contentPage.Prev, sort)
 	}
 
 	defaultSuccessReponse.Meta.Status = true
@@ -163,35 +347,33 @@ func (ch *contentHandler) GetContentWithQuery(c *fiber.Ctx) error {
 		respContent := response.ContentResponse{
 			ID:           content.ID,
 			Title:        content.Title,
+			Slug:         content.Slug,
 			Excerpt:      content.Excerpt,
 			Description:  content.Description,
 			Image:        content.Image,
-			Tags:         content.Tags,
+			Tags:         toTagResponses(content.Tags),
 			Status:       content.Status,
 			IsValid:      content.IsValid,
 			CategoryID:   content.CategoryID,
 			CreatedByID:  content.CreatedByID,
 			CreatedAt:    content.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:    formatOptionalTime(content.UpdatedAt),
 			CategoryName: content.Category.Title,
 			Author:       content.User.Name,
+			Highlight:    toContentHighlightResponse(content.Highlight),
 		}
 
 		respContents = append(respContents, respContent)
 	}
 
 	defaultSuccessReponse.Data = respContents
-	defaultSuccessReponse.Pagination = &response.PaginationResponse{
-		TotalRecords: int(totalData),
-		Page:         page,
-		PerPage:      limit,
-		TotalPages:   int(totalPages),
-	}
+	defaultSuccessReponse.Pagination = paginationResp
 	return c.JSON(defaultSuccessReponse)
 }
 
 // CreateContent implements ContentHandler.
 func (ch *contentHandler) CreateContent(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] CreateContent - 1"
 		log.Errorw(code, err)
@@ -221,16 +403,16 @@ func (ch *contentHandler) CreateContent(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
-	tags := strings.Split(req.Tags, ",")
 	reqEntity := entity.ContentEntity{
 		Title:       req.Title,
 		Excerpt:     req.Excerpt,
 		Description: req.Description,
 		Image:       req.Image,
-		Tags:        tags,
-		Status:      req.Status,
-		IsValid: 	 req.IsValid,
+		Tags:        toTagEntities(req.Tags),
 		CategoryID:  req.CategoryID,
+		PublishAt:   req.PublishAt.Value,
+		UnpublishAt: req.UnpublishAt.Value,
+		Sources:     toContentSourceEntities(req.Sources),
 		CreatedByID: int64(userID),
 	}
 
@@ -241,6 +423,10 @@ func (ch *contentHandler) CreateContent(c *fiber.Ctx) error {
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
 
+		if errors.Is(err, service.ErrInvalidSchedule) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
 	}
 
@@ -253,7 +439,7 @@ func (ch *contentHandler) CreateContent(c *fiber.Ctx) error {
 
 // DeleteContent implements ContentHandler.
 func (ch *contentHandler) DeleteContent(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] DeleteContent - 1"
 		log.Errorw(code, err)
@@ -293,7 +479,7 @@ func (ch *contentHandler) DeleteContent(c *fiber.Ctx) error {
 
 // GetContentByID implements ContentHandler.
 func (ch *contentHandler) GetContentByID(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] GetContentByID - 1"
 		log.Errorw(code, err)
@@ -330,17 +516,24 @@ func (ch *contentHandler) GetContentByID(c *fiber.Ctx) error {
 	respContent := response.ContentResponse{
 		ID:           result.ID,
 		Title:        result.Title,
+		Slug:         result.Slug,
 		Excerpt:      result.Excerpt,
 		Description:  result.Description,
 		Image:        result.Image,
-		Tags:         result.Tags,
+		Tags:         toTagResponses(result.Tags),
 		Status:       result.Status,
 		IsValid: 	  result.IsValid,
 		CategoryID:   result.CategoryID,
 		CreatedByID:  result.CreatedByID,
+		PublishAt:    formatOptionalTime(result.PublishAt),
+		UnpublishAt:  formatOptionalTime(result.UnpublishAt),
 		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    formatOptionalTime(result.UpdatedAt),
 		CategoryName: result.Category.Title,
 		Author:       result.User.Name,
+		FactCheck:    toFactCheckResponse(result.FactCheck),
+		Sources:      toContentSourceResponses(result.Sources),
+		Corrections:  toCorrectionResponses(result.Corrections),
 	}
 
 	defaultSuccessReponse.Data = respContent
@@ -349,7 +542,7 @@ func (ch *contentHandler) GetContentByID(c *fiber.Ctx) error {
 
 // GetContents implements ContentHandler.
 func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] GetContents - 1"
 		log.Errorw(code, err)
@@ -362,7 +555,7 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 	page := 1
 	if c.Query("page") != "" {
 		page, err = conv.StringToInt(c.Query("page"))
-		if err != nil {
+		if err != nil || page < 1 {
 			code := "[HANDLER] GetContents - 2"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
@@ -375,7 +568,7 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 	limit := 10
 	if c.Query("limit") != "" {
 		limit, err = conv.StringToInt(c.Query("limit"))
-		if err != nil {
+		if err != nil || limit < 1 {
 			code := "[HANDLER] GetContents - 3"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
@@ -384,15 +577,16 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 		}
 	}
+	limit = min(limit, maxContentLimit)
 
-	orderBy := "created_at"
-	if c.Query("orderBy") != "" {
-		orderBy = c.Query("orderBy")
-	}
+	sort, err := parseSort(c, adminContentSorts, "-"+entity.ContentSortCreatedAt)
+	if err != nil {
+		code := "[HANDLER] GetContents - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
 
-	orderType := "desc"
-	if c.Query("orderType") != "" {
-		orderType = c.Query("orderType")
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
 	search := ""
@@ -404,7 +598,7 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 	if c.Query("categoryID") != "" {
 		categoryID, err = conv.StringToInt(c.Query("categoryID"))
 		if err != nil {
-			code := "[HANDLER] GetContents - 4"
+			code := "[HANDLER] GetContents - 5"
 			log.Errorw(code, err)
 			errorResp.Meta.Status = false
 			errorResp.Meta.Message = "Invalid category ID"
@@ -413,18 +607,42 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 		}
 	}
 
+	sourceDomain := ""
+	if c.Query("sourceDomain") != "" {
+		sourceDomain = conv.UrlDomain(c.Query("sourceDomain"))
+		if sourceDomain == "" {
+			code := "[HANDLER] GetContents - 6"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid source domain"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	status := c.Query("status")
+	if status != "" && !slices.Contains(entity.ContentStatuses, status) {
+		code := "[HANDLER] GetContents - 7"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid status, use one of " + strings.Join(entity.ContentStatuses, ", ")
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
 	reqEntity := entity.QueryString{
-		Limit:      limit,
-		Page:       page,
-		OrderBy:    orderBy,
-		OrderType:  orderType,
-		Search:     search,
-		CategoryID: int64(categoryID),
+		Limit:        limit,
+		Page:         page,
+		Sort:         sort,
+		Search:       search,
+		CategoryID:   int64(categoryID),
+		Status:       status,
+		SourceDomain: sourceDomain,
 	}
 
 	results, totalData, totalPages, err := ch.contentService.GetContents(c.Context(), reqEntity)
 	if err != nil {
-		code := "[HANDLER] GetContents - 5"
+		code := "[HANDLER] GetContents - 8"
 		log.Errorw(code, err)
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
@@ -440,17 +658,22 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 		respContent := response.ContentResponse{
 			ID:           content.ID,
 			Title:        content.Title,
+			Slug:         content.Slug,
 			Excerpt:      content.Excerpt,
 			Description:  content.Description,
 			Image:        content.Image,
-			Tags:         content.Tags,
+			Tags:         toTagResponses(content.Tags),
 			Status:       content.Status,
 			IsValid: 	  content.IsValid,
 			CategoryID:   content.CategoryID,
 			CreatedByID:  content.CreatedByID,
+			PublishAt:    formatOptionalTime(content.PublishAt),
+			UnpublishAt:  formatOptionalTime(content.UnpublishAt),
 			CreatedAt:    content.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:    formatOptionalTime(content.UpdatedAt),
 			CategoryName: content.Category.Title,
 			Author:       content.User.Name,
+			Highlight:    toContentHighlightResponse(content.Highlight),
 		}
 
 		respContents = append(respContents, respContent)
@@ -468,7 +691,7 @@ func (ch *contentHandler) GetContents(c *fiber.Ctx) error {
 
 // UpdateContent implements ContentHandler.
 func (ch *contentHandler) UpdateContent(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] UpdateContent - 1"
 		log.Errorw(code, err)
@@ -509,27 +732,41 @@ func (ch *contentHandler) UpdateContent(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
-	tags := strings.Split(req.Tags, ",")
 	reqEntity := entity.ContentEntity{
-		ID:          contentID,
-		Title:       req.Title,
-		Excerpt:     req.Excerpt,
-		Description: req.Description,
-		Image:       req.Image,
-		Tags:        tags,
-		Status:      req.Status,
-		IsValid: 	 req.IsValid,
-		CategoryID:  req.CategoryID,
-		CreatedByID: int64(userID),
+		ID:              contentID,
+		Title:           req.Title,
+		Excerpt:         req.Excerpt,
+		Description:     req.Description,
+		Image:           req.Image,
+		Tags:            toTagEntities(req.Tags),
+		CategoryID:      req.CategoryID,
+		PublishAt:       req.PublishAt.Value,
+		UnpublishAt:     req.UnpublishAt.Value,
+		KeepPublishAt:   !req.PublishAt.Set,
+		KeepUnpublishAt: !req.UnpublishAt.Set,
+		Sources:         toContentSourceEntities(req.Sources),
+	}
+
+	actor := entity.UserEntity{
+		ID:   int64(userID),
+		Role: claims.Role,
 	}
 
-	err = ch.contentService.UpdateContent(c.Context(), reqEntity)
+	err = ch.contentService.UpdateContent(c.Context(), reqEntity, actor)
 	if err != nil {
 		code = "[HANDLER] UpdateContent - 5"
 		log.Errorw(code, err)
 		errorResp.Meta.Status = false
 		errorResp.Meta.Message = err.Error()
 
+		if errors.Is(err, service.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrStaleContentStatus) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
 		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
 	}
 
@@ -542,7 +779,7 @@ func (ch *contentHandler) UpdateContent(c *fiber.Ctx) error {
 
 // UploadImageR2 implements ContentHandler.
 func (ch *contentHandler) UploadImageR2(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] UploadImageR2 - 1"
 		log.Errorw(code, err)
@@ -611,6 +848,628 @@ func (ch *contentHandler) UploadImageR2(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(defaultSuccessReponse)
 }
 
-func NewContentHandler(contentService service.ContentService) ContentHandler {
-	return &contentHandler{contentService: contentService}
+// GetRevisions implements ContentHandler.
+func (ch *contentHandler) GetRevisions(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetRevisions - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetRevisions - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid page number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetRevisions - 3"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid limit number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.QueryString{
+		Limit: limit,
+		Page:  page,
+	}
+
+	results, totalData, totalPages, err := ch.contentService.GetRevisions(c.Context(), contentID, reqEntity)
+	if err != nil {
+		code = "[HANDLER] GetRevisions - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respRevisions := []response.ContentRevisionResponse{}
+	for _, revision := range results {
+		respRevisions = append(respRevisions, toContentRevisionResponse(revision))
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respRevisions
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetRevision implements ContentHandler.
+func (ch *contentHandler) GetRevision(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetRevision - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	revisionNumber, err := conv.StringToInt(c.Params("revisionNumber"))
+	if err != nil {
+		code = "[HANDLER] GetRevision - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Revision Number"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := ch.contentService.GetRevision(c.Context(), contentID, revisionNumber)
+	if err != nil {
+		code = "[HANDLER] GetRevision - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Revision Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toContentRevisionResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetRevisionDiff implements ContentHandler. Both revisions are given with the
+// from and to query parameters.
+func (ch *contentHandler) GetRevisionDiff(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetRevisionDiff - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	from, err := conv.StringToInt(c.Query("from"))
+	if err != nil {
+		code = "[HANDLER] GetRevisionDiff - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid from Revision Number"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	to, err := conv.StringToInt(c.Query("to"))
+	if err != nil {
+		code = "[HANDLER] GetRevisionDiff - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid to Revision Number"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := ch.contentService.GetRevisionDiff(c.Context(), contentID, from, to)
+	if err != nil {
+		code = "[HANDLER] GetRevisionDiff - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Revision Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = response.ContentRevisionDiffResponse{
+		From:    toContentRevisionResponse(result.From),
+		To:      toContentRevisionResponse(result.To),
+		Changes: result.Changes,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// RestoreRevision implements ContentHandler.
+func (ch *contentHandler) RestoreRevision(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] RestoreRevision - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] RestoreRevision - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	revisionNumber, err := conv.StringToInt(c.Params("revisionNumber"))
+	if err != nil {
+		code = "[HANDLER] RestoreRevision - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Revision Number"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	newRevision, err := ch.contentService.RestoreRevision(c.Context(), contentID, revisionNumber, actor)
+	if err != nil {
+		code = "[HANDLER] RestoreRevision - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrStaleContentStatus) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Revision Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Revision Restored"
+	defaultSuccessReponse.Data = response.RestoredRevisionResponse{RevisionNumber: newRevision}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// SubmitContent implements ContentHandler.
+func (ch *contentHandler) SubmitContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionSubmit, "Content Submitted For Review")
+}
+
+// ApproveContent implements ContentHandler.
+func (ch *contentHandler) ApproveContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionApprove, "Content Approved")
+}
+
+// RejectContent implements ContentHandler. It sends the content back to draft.
+func (ch *contentHandler) RejectContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionReject, "Content Sent Back To Draft")
+}
+
+// ScheduleContent implements ContentHandler.
+func (ch *contentHandler) ScheduleContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionSchedule, "Content Scheduled")
+}
+
+// PublishContent implements ContentHandler.
+func (ch *contentHandler) PublishContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionPublish, "Content Published")
+}
+
+// ArchiveContent implements ContentHandler.
+func (ch *contentHandler) ArchiveContent(c *fiber.Ctx) error {
+	return ch.transitionContent(c, entity.ContentActionArchive, "Content Archived")
+}
+
+func (ch *contentHandler) transitionContent(c *fiber.Ctx, action, message string) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] transitionContent - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] transitionContent - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	// the comment is optional for most actions, so an empty body is fine
+	var req request.ContentReviewRequest
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			code = "[HANDLER] transitionContent - 3"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Request Body"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	if err = validatorLib.ValidateStruct(&req); err != nil {
+		code = "[HANDLER] transitionContent - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	result, err := ch.contentService.TransitionContent(c.Context(), contentID, action, req.Comment, actor)
+	if err != nil {
+		code = "[HANDLER] transitionContent - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrForbidden) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrInvalidTransition) || errors.Is(err, service.ErrStaleContentStatus) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrReviewCommentNeeded) || errors.Is(err, service.ErrPublishAtRequired) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = message
+	defaultSuccessReponse.Data = response.ContentResponse{
+		ID:           result.ID,
+		Title:        result.Title,
+		Slug:         result.Slug,
+		Excerpt:      result.Excerpt,
+		Image:        result.Image,
+		Tags:         toTagResponses(result.Tags),
+		Status:       result.Status,
+		IsValid:      result.IsValid,
+		CategoryID:   result.CategoryID,
+		CreatedByID:  result.CreatedByID,
+		PublishAt:    formatOptionalTime(result.PublishAt),
+		UnpublishAt:  formatOptionalTime(result.UnpublishAt),
+		CreatedAt:    result.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    formatOptionalTime(result.UpdatedAt),
+		CategoryName: result.Category.Title,
+		Author:       result.User.Name,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetContentReviews implements ContentHandler. It returns the workflow history.
+func (ch *contentHandler) GetContentReviews(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetContentReviews - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	results, err := ch.contentService.GetContentReviews(c.Context(), contentID)
+	if err != nil {
+		code = "[HANDLER] GetContentReviews - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respReviews := []response.ContentReviewResponse{}
+	for _, review := range results {
+		respReview := response.ContentReviewResponse{
+			ID:         review.ID,
+			Action:     review.Action,
+			FromStatus: review.FromStatus,
+			ToStatus:   review.ToStatus,
+			Comment:    review.Comment,
+			CreatedAt:  review.CreatedAt.Format(time.RFC3339),
+		}
+		if review.Reviewer != nil {
+			reviewer := toUserResponse(*review.Reviewer)
+			respReview.Reviewer = &reviewer
+		}
+
+		respReviews = append(respReviews, respReview)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respReviews
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// toContentSourceEntities keeps a missing list nil, so an update leaves the
+// sources alone, while an empty list clears them.
+func toContentSourceEntities(sources []request.ContentSourceRequest) []entity.ContentSourceEntity {
+	if sources == nil {
+		return nil
+	}
+
+	resps := []entity.ContentSourceEntity{}
+	for _, source := range sources {
+		resps = append(resps, entity.ContentSourceEntity{
+			Url:        strings.TrimSpace(source.Url),
+			Publisher:  strings.TrimSpace(source.Publisher),
+			AccessedAt: source.AccessedAt,
+			ArchiveUrl: strings.TrimSpace(source.ArchiveUrl),
+			Note:       strings.TrimSpace(source.Note),
+		})
+	}
+
+	return resps
+}
+
+// parseSort reads the sort query, e.g. "-published_at,title". The older
+// orderBy and orderType pair is still accepted and checked the same way.
+func parseSort(c *fiber.Ctx, allowed []string, fallback string) ([]sorting.Key, error) {
+	raw := c.Query("sort")
+	if raw == "" && c.Query("orderBy") != "" {
+		raw = c.Query("orderBy")
+		if !strings.EqualFold(c.Query("orderType"), "asc") {
+			raw = "-" + raw
+		}
+	}
+	if raw == "" {
+		raw = fallback
+	}
+
+	return sorting.Parse(raw, allowed)
+}
+
+// decodeCursor reads a cursor handed out with the contents. It has to be used
+// with the sort it was taken with, its values mean nothing for another one.
+func (ch *contentHandler) decodeCursor(raw string, sort []sorting.Key, search string) (*entity.CursorEntity, error) {
+	cursor, err := pagination.DecodeCursor(raw, ch.cursorKey, contentSortKinds(sorting.WithTiebreak(sort, entity.ContentSortID), search != ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort != sorting.Format(sort) {
+		return nil, pagination.ErrorCursorInvalid
+	}
+
+	return &entity.CursorEntity{Values: cursor.Values, Backward: cursor.Backward}, nil
+}
+
+func (ch *contentHandler) encodeCursor(cursor *entity.CursorEntity, sort []sorting.Key) string {
+	if cursor == nil {
+		return ""
+	}
+
+	return pagination.Cursor{Sort: sorting.Format(sort), Values: cursor.Values, Backward: cursor.Backward}.Encode(ch.cursorKey)
+}
+
+// contentSortKinds returns the type of the cursor value of each key, as the
+// content repository fills them in. Relevance falls back to the creation date
+// without a search.
+func contentSortKinds(keys []sorting.Key, search bool) []pagination.Kind {
+	kinds := []pagination.Kind{}
+	for _, key := range keys {
+		switch key.Name {
+		case entity.ContentSortTitle, entity.ContentSortStatus:
+			kinds = append(kinds, pagination.KindString)
+		case entity.ContentSortCreatedAt, entity.ContentSortUpdatedAt, entity.ContentSortPublishedAt:
+			kinds = append(kinds, pagination.KindTime)
+		case entity.ContentSortRelevance:
+			if search {
+				kinds = append(kinds, pagination.KindFloat)
+			} else {
+				kinds = append(kinds, pagination.KindTime)
+			}
+		default:
+			kinds = append(kinds, pagination.KindInt)
+		}
+	}
+
+	return kinds
+}
+
+func toContentSourceResponses(sources []entity.ContentSourceEntity) []response.ContentSourceResponse {
+	resps := []response.ContentSourceResponse{}
+	for _, source := range sources {
+		resps = append(resps, response.ContentSourceResponse{
+			Url:        source.Url,
+			Domain:     source.Domain,
+			Publisher:  source.Publisher,
+			AccessedAt: formatOptionalTime(source.AccessedAt),
+			ArchiveUrl: source.ArchiveUrl,
+			Note:       source.Note,
+		})
+	}
+
+	return resps
+}
+
+// toContentDetailResponse builds the reader facing detail of a published content.
+func toContentDetailResponse(content entity.ContentEntity) response.ContentResponse {
+	return response.ContentResponse{
+		ID:           content.ID,
+		Title:        content.Title,
+		Slug:         content.Slug,
+		Excerpt:      content.Excerpt,
+		Description:  content.Description,
+		Image:        content.Image,
+		Tags:         toTagResponses(content.Tags),
+		Status:       content.Status,
+		IsValid: 	  content.IsValid,
+		CategoryID:   content.CategoryID,
+		CreatedByID:  content.CreatedByID,
+		PublishAt:    formatOptionalTime(content.PublishAt),
+		CreatedAt:    content.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    formatOptionalTime(content.UpdatedAt),
+		CategoryName: content.Category.Title,
+		Author:       content.User.Name,
+		FactCheck:    toFactCheckResponse(content.FactCheck),
+		Sources:      toContentSourceResponses(content.Sources),
+		Corrections:  toCorrectionResponses(content.Corrections),
+	}
+}
+
+// redirectToSlug answers a request for an old slug with 301 Moved Permanently,
+// the query string is kept.
+func redirectToSlug(c *fiber.Ctx, slug, location string) error {
+	if query := string(c.Request().URI().QueryString()); query != "" {
+		location += "?" + query
+	}
+
+	c.Location(location)
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Moved Permanently"
+	defaultSuccessReponse.Data = response.SlugRedirectResponse{
+		Slug:     slug,
+		Location: location,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.Status(fiber.StatusMovedPermanently).JSON(defaultSuccessReponse)
+}
+
+func toContentHighlightResponse(highlight *entity.ContentHighlightEntity) *response.ContentHighlightResponse {
+	if highlight == nil {
+		return nil
+	}
+
+	return &response.ContentHighlightResponse{
+		Title:   highlight.Title,
+		Snippet: highlight.Snippet,
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func toContentRevisionResponse(revision entity.ContentRevisionEntity) response.ContentRevisionResponse {
+	resp := response.ContentRevisionResponse{
+		ID:             revision.ID,
+		ContentID:      revision.ContentID,
+		RevisionNumber: revision.RevisionNumber,
+		Title:          revision.Title,
+		Excerpt:        revision.Excerpt,
+		Description:    revision.Description,
+		Image:          revision.Image,
+		Tags:           revision.Tags,
+		Sources:        toContentSourceResponses(revision.Sources),
+		Status:         revision.Status,
+		IsValid:        revision.IsValid,
+		CategoryID:     revision.CategoryID,
+		RestoredFrom:   revision.RestoredFrom,
+		CreatedAt:      revision.CreatedAt.Format(time.RFC3339),
+	}
+	if revision.EditedBy != nil {
+		editedBy := toUserResponse(*revision.EditedBy)
+		resp.EditedBy = &editedBy
+	}
+
+	return resp
+}
+
+func NewContentHandler(contentService service.ContentService, categoryService service.CategoryService, tagService service.TagService, cursorKey []byte) ContentHandler {
+	return &contentHandler{contentService: contentService, categoryService: categoryService, tagService: tagService, cursorKey: cursorKey}
 }
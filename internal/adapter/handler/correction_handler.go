@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type CorrectionHandler interface {
+	GetCorrections(c *fiber.Ctx) error
+	CreateCorrection(c *fiber.Ctx) error
+	DeleteCorrection(c *fiber.Ctx) error
+
+	// FE
+	GetPublishedCorrections(c *fiber.Ctx) error
+}
+
+type correctionHandler struct {
+	correctionService service.CorrectionService
+}
+
+// GetCorrections implements CorrectionHandler.
+func (ch *correctionHandler) GetCorrections(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetCorrections - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	results, err := ch.correctionService.GetCorrections(c.Context(), contentID)
+	if err != nil {
+		code = "[HANDLER] GetCorrections - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toCorrectionResponses(results)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// CreateCorrection implements CorrectionHandler.
+func (ch *correctionHandler) CreateCorrection(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] CreateCorrection - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] CreateCorrection - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.CorrectionRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateCorrection - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	req.Severity = strings.ToUpper(strings.TrimSpace(req.Severity))
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateCorrection - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.ContentCorrectionEntity{
+		ContentID:    contentID,
+		WhatWasWrong: strings.TrimSpace(req.WhatWasWrong),
+		WhatChanged:  strings.TrimSpace(req.WhatChanged),
+		Severity:     req.Severity,
+		CreatedByID:  claims.UserID,
+	}
+	if req.CorrectedAt != nil {
+		reqEntity.CorrectedAt = *req.CorrectedAt
+	}
+
+	result, err := ch.correctionService.CreateCorrection(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] CreateCorrection - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Correction Added"
+	defaultSuccessReponse.Data = toCorrectionResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.Status(fiber.StatusCreated).JSON(defaultSuccessReponse)
+}
+
+// DeleteCorrection implements CorrectionHandler.
+func (ch *correctionHandler) DeleteCorrection(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] DeleteCorrection - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	correctionID, err := conv.StringToInt64(c.Params("correctionID"))
+	if err != nil {
+		code = "[HANDLER] DeleteCorrection - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = ch.correctionService.DeleteCorrection(c.Context(), contentID, correctionID)
+	if err != nil {
+		code = "[HANDLER] DeleteCorrection - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Correction Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Correction Deleted"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetPublishedCorrections implements CorrectionHandler.
+func (ch *correctionHandler) GetPublishedCorrections(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetPublishedCorrections - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Page Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetPublishedCorrections - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Limit Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.QueryString{
+		Limit: limit,
+		Page:  page,
+	}
+
+	results, totalData, totalPages, err := ch.correctionService.GetPublishedCorrections(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] GetPublishedCorrections - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toCorrectionResponses(results)
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func toCorrectionResponse(correction entity.ContentCorrectionEntity) response.CorrectionResponse {
+	resp := response.CorrectionResponse{
+		ID:           correction.ID,
+		ContentID:    correction.ContentID,
+		ContentTitle: correction.ContentTitle,
+		CorrectedAt:  correction.CorrectedAt.Format(time.RFC3339),
+		WhatWasWrong: correction.WhatWasWrong,
+		WhatChanged:  correction.WhatChanged,
+		Severity:     correction.Severity,
+	}
+	if correction.CreatedBy != nil {
+		createdBy := toUserResponse(*correction.CreatedBy)
+		resp.CreatedBy = &createdBy
+	}
+
+	return resp
+}
+
+func toCorrectionResponses(corrections []entity.ContentCorrectionEntity) []response.CorrectionResponse {
+	resps := []response.CorrectionResponse{}
+	for _, correction := range corrections {
+		resps = append(resps, toCorrectionResponse(correction))
+	}
+
+	return resps
+}
+
+func NewCorrectionHandler(correctionService service.CorrectionService) CorrectionHandler {
+	return &correctionHandler{
+		correctionService: correctionService,
+	}
+}
@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type FactCheckHandler interface {
+	GetFactCheck(c *fiber.Ctx) error
+	SaveFactCheck(c *fiber.Ctx) error
+	DeleteFactCheck(c *fiber.Ctx) error
+
+	// FE
+	GetClaimReview(c *fiber.Ctx) error
+}
+
+type factCheckHandler struct {
+	factCheckService service.FactCheckService
+}
+
+// GetFactCheck implements FactCheckHandler.
+func (f *factCheckHandler) GetFactCheck(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetFactCheck - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := f.factCheckService.GetFactCheck(c.Context(), contentID)
+	if err != nil {
+		code = "[HANDLER] GetFactCheck - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Fact Check Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toFactCheckResponse(result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// SaveFactCheck implements FactCheckHandler. It creates the fact check of a
+// content or replaces the existing one.
+func (f *factCheckHandler) SaveFactCheck(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] SaveFactCheck - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] SaveFactCheck - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.FactCheckRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] SaveFactCheck - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	// "mostly true" and "mostly_true" are accepted as well as MOSTLY_TRUE
+	req.Rating = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(req.Rating), " ", "_"))
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] SaveFactCheck - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.FactCheckEntity{
+		ContentID:     contentID,
+		Claim:         strings.TrimSpace(req.Claim),
+		Claimant:      strings.TrimSpace(req.Claimant),
+		Rating:        req.Rating,
+		EvidenceLinks: req.EvidenceLinks,
+	}
+	if reqEntity.EvidenceLinks == nil {
+		reqEntity.EvidenceLinks = []string{}
+	}
+	if req.ReviewedAt != nil {
+		reqEntity.ReviewedAt = *req.ReviewedAt
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	result, err := f.factCheckService.SaveFactCheck(c.Context(), reqEntity, actor)
+	if err != nil {
+		code = "[HANDLER] SaveFactCheck - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Fact Check Saved"
+	defaultSuccessReponse.Data = toFactCheckResponse(result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// DeleteFactCheck implements FactCheckHandler.
+func (f *factCheckHandler) DeleteFactCheck(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] DeleteFactCheck - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = f.factCheckService.DeleteFactCheck(c.Context(), contentID)
+	if err != nil {
+		code = "[HANDLER] DeleteFactCheck - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Fact Check Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Fact Check Deleted"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetClaimReview implements FactCheckHandler. The ClaimReview is served bare as
+// application/ld+json so it can be embedded in the article page as is.
+func (f *factCheckHandler) GetClaimReview(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] GetClaimReview - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := f.factCheckService.GetClaimReview(c.Context(), contentID)
+	if err != nil {
+		code = "[HANDLER] GetClaimReview - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Fact Check Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	factCheck := result.FactCheck
+	claimReview := response.ClaimReviewResponse{
+		Context:       "https://schema.org",
+		Type:          "ClaimReview",
+		Url:           result.Url,
+		Headline:      result.Title,
+		ClaimReviewed: factCheck.Claim,
+		DatePublished: factCheck.ReviewedAt.Format(time.DateOnly),
+		Author: response.SchemaThing{
+			Type: "Organization",
+			Name: result.SiteName,
+			Url:  result.SiteUrl,
+		},
+		ReviewRating: response.SchemaRating{
+			Type:          "Rating",
+			AlternateName: entity.FactCheckRatingLabels[factCheck.Rating],
+		},
+		ItemReviewed: response.SchemaClaim{
+			Type: "Claim",
+			Author: response.SchemaThing{
+				Type: "Person",
+				Name: factCheck.Claimant,
+			},
+		},
+		Citation: factCheck.EvidenceLinks,
+	}
+	if value, ok := entity.FactCheckRatingValues[factCheck.Rating]; ok {
+		claimReview.ReviewRating.RatingValue = value
+		claimReview.ReviewRating.BestRating = 5
+		claimReview.ReviewRating.WorstRating = 1
+	}
+
+	return c.JSON(claimReview, "application/ld+json")
+}
+
+func toFactCheckResponse(factCheck *entity.FactCheckEntity) *response.FactCheckResponse {
+	if factCheck == nil {
+		return nil
+	}
+
+	resp := response.FactCheckResponse{
+		Claim:         factCheck.Claim,
+		Claimant:      factCheck.Claimant,
+		Rating:        factCheck.Rating,
+		RatingLabel:   entity.FactCheckRatingLabels[factCheck.Rating],
+		ReviewedAt:    factCheck.ReviewedAt.Format(time.RFC3339),
+		EvidenceLinks: factCheck.EvidenceLinks,
+	}
+	if factCheck.Reviewer != nil {
+		reviewer := toUserResponse(*factCheck.Reviewer)
+		resp.Reviewer = &reviewer
+	}
+
+	return &resp
+}
+
+func NewFactCheckHandler(factCheckService service.FactCheckService) FactCheckHandler {
+	return &factCheckHandler{
+		factCheckService: factCheckService,
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"time"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type LockoutHandler interface {
+	GetLockoutEvents(c *fiber.Ctx) error
+}
+
+type lockoutHandler struct {
+	lockoutService service.LockoutService
+}
+
+// GetLockoutEvents implements LockoutHandler.
+func (l *lockoutHandler) GetLockoutEvents(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetLockoutEvents - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid page number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetLockoutEvents - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid limit number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	scope := c.Query("scope")
+	if scope != "" && scope != entity.ThrottleScopeIP && scope != entity.ThrottleScopeAccount {
+		code = "[HANDLER] GetLockoutEvents - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid scope, use ip or account"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.QueryString{
+		Limit:  limit,
+		Page:   page,
+		Search: c.Query("search"),
+	}
+
+	results, totalData, totalPages, err := l.lockoutService.GetLockoutEvents(c.Context(), reqEntity, scope)
+	if err != nil {
+		code = "[HANDLER] GetLockoutEvents - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respEvents := []response.LockoutEventResponse{}
+	for _, event := range results {
+		respEvents = append(respEvents, response.LockoutEventResponse{
+			ID:          event.ID,
+			Scope:       event.Scope,
+			Identifier:  event.Identifier,
+			UserID:      event.UserID,
+			IPAddress:   event.IPAddress,
+			Failures:    event.Failures,
+			LockedUntil: event.LockedUntil.Format(time.RFC3339),
+			CreatedAt:   event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respEvents
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func NewLockoutHandler(lockoutService service.LockoutService) LockoutHandler {
+	return &lockoutHandler{
+		lockoutService: lockoutService,
+	}
+}
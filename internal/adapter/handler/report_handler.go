@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ReportHandler interface {
+	GetReports(c *fiber.Ctx) error
+	GetReportByID(c *fiber.Ctx) error
+	ModerateReport(c *fiber.Ctx) error
+
+	// FE
+	CreateReport(c *fiber.Ctx) error
+}
+
+type reportHandler struct {
+	reportService service.ReportService
+}
+
+// GetReports implements ReportHandler.
+func (rh *reportHandler) GetReports(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetReports - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Page Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetReports - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Limit Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	status := strings.ToUpper(c.Query("status"))
+	if status != "" && !slices.Contains(entity.ReportStatuses, status) {
+		code = "[HANDLER] GetReports - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Status, Use One Of " + strings.Join(entity.ReportStatuses, ", ")
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var contentID int64
+	if c.Query("contentID") != "" {
+		contentID, err = conv.StringToInt64(c.Query("contentID"))
+		if err != nil {
+			code = "[HANDLER] GetReports - 4"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Content ID"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.ContentReportQuery{
+		Limit:     limit,
+		Page:      page,
+		Status:    status,
+		ContentID: contentID,
+	}
+
+	results, totalData, totalPages, err := rh.reportService.GetReports(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] GetReports - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	resps := []response.ReportResponse{}
+	for _, result := range results {
+		resps = append(resps, toReportResponse(result))
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = resps
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetReportByID implements ReportHandler.
+func (rh *reportHandler) GetReportByID(c *fiber.Ctx) error {
+	reportID, err := conv.StringToInt64(c.Params("reportID"))
+	if err != nil {
+		code = "[HANDLER] GetReportByID - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	result, err := rh.reportService.GetReportByID(c.Context(), reportID)
+	if err != nil {
+		code = "[HANDLER] GetReportByID - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Report Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toReportResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// ModerateReport implements ReportHandler.
+func (rh *reportHandler) ModerateReport(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] ModerateReport - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	reportID, err := conv.StringToInt64(c.Params("reportID"))
+	if err != nil {
+		code = "[HANDLER] ModerateReport - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.ModerateReportRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] ModerateReport - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	req.Status = strings.ToUpper(strings.TrimSpace(req.Status))
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] ModerateReport - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	result, err := rh.reportService.ModerateReport(c.Context(), reportID, req.Status, strings.TrimSpace(req.Note), actor)
+	if err != nil {
+		code = "[HANDLER] ModerateReport - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Report Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrInvalidReportStatus) || errors.Is(err, service.ErrStaleReportStatus) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Report Moderated"
+	defaultSuccessReponse.Data = toReportResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// CreateReport implements ReportHandler.
+func (rh *reportHandler) CreateReport(c *fiber.Ctx) error {
+	contentID, err := conv.StringToInt64(c.Params("contentID"))
+	if err != nil {
+		code = "[HANDLER] CreateReport - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.ReportRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] CreateReport - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	req.Reason = strings.ToUpper(strings.TrimSpace(req.Reason))
+	req.Details = strings.TrimSpace(req.Details)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] CreateReport - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.ContentReportEntity{
+		ContentID: contentID,
+		Reason:    req.Reason,
+		Details:   req.Details,
+		Email:     req.Email,
+	}
+
+	err = rh.reportService.CreateReport(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] CreateReport - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Content Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Thank You, Your Report Was Received"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.Status(fiber.StatusCreated).JSON(defaultSuccessReponse)
+}
+
+func toReportResponse(report entity.ContentReportEntity) response.ReportResponse {
+	resp := response.ReportResponse{
+		ID:            report.ID,
+		ContentID:     report.ContentID,
+		ContentTitle:  report.ContentTitle,
+		Reason:        report.Reason,
+		Details:       report.Details,
+		Email:         report.Email,
+		IPAddress:     report.IPAddress,
+		UserAgent:     report.UserAgent,
+		Status:        report.Status,
+		ModeratorNote: report.ModeratorNote,
+		ResolvedAt:    formatOptionalTime(report.ResolvedAt),
+		CreatedAt:     report.CreatedAt.Format(time.RFC3339),
+	}
+	if report.Moderator != nil {
+		moderator := toUserResponse(*report.Moderator)
+		resp.Moderator = &moderator
+	}
+
+	return resp
+}
+
+func NewReportHandler(reportService service.ReportService) ReportHandler {
+	return &reportHandler{
+		reportService: reportService,
+	}
+}
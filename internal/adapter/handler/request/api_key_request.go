@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type ApiKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=contents:read contents:write contents:delete categories:read categories:write media:upload"`
+	UserID    int64      `json:"user_id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
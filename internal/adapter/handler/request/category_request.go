@@ -1,5 +1,12 @@
 package request
 
+// CategoryRequest leaves out what an update keeps, a null parent_id makes the
+// category a top level one.
 type CategoryRequest struct {
 	Title string `json:"title" validate:"required"`
+	ParentID Optional[int64] `json:"parent_id"`
+	SortOrder *int `json:"sort_order"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+	SeoTitle *string `json:"seo_title" validate:"omitempty,max=200"`
+	SeoDescription *string `json:"seo_description" validate:"omitempty,max=320"`
 }
\ No newline at end of file
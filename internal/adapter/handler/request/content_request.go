@@ -1,12 +1,32 @@
 package request
 
+import "time"
+
 type ContentRequest struct {
 	Title       string `json:"title" validate:"required"`
 	Excerpt     string `json:"excerpt" validate:"required"`
 	Description string `json:"description" validate:"required"`
 	Image       string `json:"image" validate:"required"`
-	Tags        string `json:"tags"`
 	CategoryID  int64  `json:"category_id" validate:"required"`
-	Status      string `json:"status" validate:"required"`
-	IsValid     string `json:"is_valid" validate:"required"`
+	// Tags is comma separated, an update keeps the tags when it is left out
+	// and null or an empty string clears them
+	Tags Optional[string] `json:"tags"`
+	// PublishAt and UnpublishAt are kept on an update when left out, null
+	// clears them
+	PublishAt   Optional[time.Time] `json:"publish_at"`
+	UnpublishAt Optional[time.Time] `json:"unpublish_at"`
+	// Sources replaces every source of the content, leave it out to keep them
+	Sources []ContentSourceRequest `json:"sources" validate:"omitempty,max=100,dive"`
+}
+
+type ContentSourceRequest struct {
+	Url        string     `json:"url" validate:"required,url,max=2000"`
+	Publisher  string     `json:"publisher" validate:"max=200"`
+	AccessedAt *time.Time `json:"accessed_at"`
+	ArchiveUrl string     `json:"archive_url" validate:"omitempty,url,max=2000"`
+	Note       string     `json:"note" validate:"max=2000"`
+}
+
+type ContentReviewRequest struct {
+	Comment string `json:"comment" validate:"max=2000"`
 }
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type CorrectionRequest struct {
+	CorrectedAt  *time.Time `json:"corrected_at"`
+	WhatWasWrong string     `json:"what_was_wrong" validate:"required,max=5000"`
+	WhatChanged  string     `json:"what_changed" validate:"required,max=5000"`
+	Severity     string     `json:"severity" validate:"required,oneof=MINOR MAJOR CRITICAL"`
+}
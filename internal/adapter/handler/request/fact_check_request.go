@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+type FactCheckRequest struct {
+	Claim         string     `json:"claim" validate:"required,max=2000"`
+	Claimant      string     `json:"claimant" validate:"required,max=200"`
+	Rating        string     `json:"rating" validate:"required,oneof=TRUE MOSTLY_TRUE MISLEADING FALSE SATIRE"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	EvidenceLinks []string   `json:"evidence_links" validate:"dive,url"`
+}
@@ -0,0 +1,20 @@
+package request
+
+import "encoding/json"
+
+// Optional tells a value sent as null, which clears it on an update, from one
+// that was left out.
+type Optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	return json.Unmarshal(data, &o.Value)
+}
@@ -0,0 +1,12 @@
+package request
+
+type ReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=FACTUAL_ERROR MISLEADING MISSING_CONTEXT OUTDATED OTHER"`
+	Details string `json:"details" validate:"required,max=5000"`
+	Email   string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type ModerateReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=TRIAGED UPHELD REJECTED"`
+	Note   string `json:"note" validate:"max=2000"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// TagRequest renames a tag. The name may not contain a comma, contents and
+// their revisions keep tag names comma separated.
+type TagRequest struct {
+	Name string `json:"name" validate:"required,max=100,excludes=0x2C"`
+}
+
+type MergeTagRequest struct {
+	TargetID int64 `json:"target_id" validate:"required"`
+}
@@ -0,0 +1,9 @@
+package request
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorRolesRequest struct {
+	Roles []string `json:"roles" validate:"dive,oneof=admin editor author fact_checker"`
+}
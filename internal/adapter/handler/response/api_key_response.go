@@ -0,0 +1,18 @@
+package response
+
+type ApiKeyResponse struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	KeyPrefix  string       `json:"key_prefix"`
+	Scopes     []string     `json:"scopes"`
+	User       UserResponse `json:"user"`
+	ExpiresAt  string       `json:"expires_at,omitempty"`
+	LastUsedAt string       `json:"last_used_at,omitempty"`
+	RevokedAt  string       `json:"revoked_at,omitempty"`
+	CreatedAt  string       `json:"created_at"`
+}
+
+type CreatedApiKeyResponse struct {
+	ID  int64  `json:"id"`
+	Key string `json:"key"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "trustnews/lib/diff"
+
+type AuditLogResponse struct {
+	ID         int64                  `json:"id"`
+	Actor      *UserResponse          `json:"actor"`
+	ApiKeyID   *int64                 `json:"api_key_id,omitempty"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int64                  `json:"entity_id"`
+	Changes    map[string]diff.Change `json:"changes"`
+	IPAddress  string                 `json:"ip_address"`
+	UserAgent  string                 `json:"user_agent"`
+	CreatedAt  string                 `json:"created_at"`
+}
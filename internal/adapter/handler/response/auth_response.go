@@ -2,6 +2,27 @@ package response
 
 type SuccessAuthResponse struct {
 	Meta
-	AccessToken string 	`json:"access_token"`
-	ExpiresAt 	int64 	`json:"expires_at"`
+	AccessToken string 	`json:"access_token,omitempty"`
+	ExpiresAt 	int64 	`json:"expires_at,omitempty"`
+	RefreshToken 		string 	`json:"refresh_token,omitempty"`
+	RefreshExpiresAt 	int64 	`json:"refresh_expires_at,omitempty"`
+	MfaRequired 		bool 	`json:"mfa_required"`
+	MfaToken 			string 	`json:"mfa_token,omitempty"`
+	MfaExpiresAt 		int64 	`json:"mfa_expires_at,omitempty"`
+	MfaSetupRequired 	bool 	`json:"mfa_setup_required,omitempty"`
+}
+
+type JwkResponse struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JwksResponse struct {
+	Keys []JwkResponse `json:"keys"`
 }
\ No newline at end of file
@@ -4,5 +4,11 @@ type SuccessCategoryResponse struct {
 	ID 				int64 	`json:"id"`
 	Title 			string 	`json:"title"`
 	Slug 			string 	`json:"slug"`
+	ParentID		*int64	`json:"parent_id"`
+	SortOrder		int		`json:"sort_order"`
+	Description		string	`json:"description"`
+	SeoTitle		string	`json:"seo_title"`
+	SeoDescription	string	`json:"seo_description"`
 	CreatedByName	string	`json:"created_by_name"`
+	Children		[]SuccessCategoryResponse	`json:"children,omitempty"`
 }
\ No newline at end of file
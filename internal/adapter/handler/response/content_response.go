@@ -1,17 +1,56 @@
 package response
 
 type ContentResponse struct {
-	ID           int64    `json:"id"`
-	Title        string   `json:"title"`
-	Excerpt      string   `json:"excerpt"`
-	Description  string   `json:"description,omitempty"`
-	Image        string   `json:"image"`
-	Tags         []string `json:"tags,omitempty"`
-	Status       string   `json:"status"`
-	IsValid      string   `json:"is_valid"`
-	CategoryID   int64    `json:"category_id,omitempty"`
-	CreatedByID  int64    `json:"created_by_id,omitempty"`
-	CreatedAt    string   `json:"created_at"`
-	CategoryName string   `json:"category_name"`
-	Author       string   `json:"author"`
+	ID           int64                     `json:"id"`
+	Title        string                    `json:"title"`
+	Slug         string                    `json:"slug"`
+	Excerpt      string                    `json:"excerpt"`
+	Description  string                    `json:"description,omitempty"`
+	Image        string                    `json:"image"`
+	Tags         []TagResponse             `json:"tags,omitempty"`
+	Status       string                    `json:"status"`
+	IsValid      string                    `json:"is_valid"`
+	CategoryID   int64                     `json:"category_id,omitempty"`
+	CreatedByID  int64                     `json:"created_by_id,omitempty"`
+	PublishAt    string                    `json:"publish_at,omitempty"`
+	UnpublishAt  string                    `json:"unpublish_at,omitempty"`
+	CreatedAt    string                    `json:"created_at"`
+	UpdatedAt    string                    `json:"updated_at,omitempty"`
+	CategoryName string                    `json:"category_name"`
+	Author       string                    `json:"author"`
+	FactCheck    *FactCheckResponse        `json:"fact_check,omitempty"`
+	Sources      []ContentSourceResponse   `json:"sources,omitempty"`
+	Corrections  []CorrectionResponse      `json:"corrections,omitempty"`
+	Highlight    *ContentHighlightResponse `json:"highlight,omitempty"`
+}
+
+type ContentHighlightResponse struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+type ContentSourceResponse struct {
+	Url        string `json:"url"`
+	Domain     string `json:"domain"`
+	Publisher  string `json:"publisher,omitempty"`
+	AccessedAt string `json:"accessed_at,omitempty"`
+	ArchiveUrl string `json:"archive_url,omitempty"`
+	Note       string `json:"note,omitempty"`
+}
+
+type ContentReviewResponse struct {
+	ID         int64         `json:"id"`
+	Action     string        `json:"action"`
+	FromStatus string        `json:"from_status"`
+	ToStatus   string        `json:"to_status"`
+	Comment    string        `json:"comment,omitempty"`
+	Reviewer   *UserResponse `json:"reviewer"`
+	CreatedAt  string        `json:"created_at"`
+}
+
+// SlugRedirectResponse is returned with 301 Moved Permanently when an old slug
+// was requested, Location points to the current one.
+type SlugRedirectResponse struct {
+	Slug     string `json:"slug"`
+	Location string `json:"location"`
 }
@@ -0,0 +1,31 @@
+package response
+
+import "trustnews/lib/diff"
+
+type ContentRevisionResponse struct {
+	ID             int64                   `json:"id"`
+	ContentID      int64                   `json:"content_id"`
+	RevisionNumber int                     `json:"revision_number"`
+	Title          string                  `json:"title"`
+	Excerpt        string                  `json:"excerpt"`
+	Description    string                  `json:"description,omitempty"`
+	Image          string                  `json:"image"`
+	Tags           []string                `json:"tags,omitempty"`
+	Sources        []ContentSourceResponse `json:"sources,omitempty"`
+	Status         string                  `json:"status"`
+	IsValid        string                  `json:"is_valid"`
+	CategoryID     int64                   `json:"category_id"`
+	EditedBy       *UserResponse           `json:"edited_by"`
+	RestoredFrom   *int                    `json:"restored_from,omitempty"`
+	CreatedAt      string                  `json:"created_at"`
+}
+
+type ContentRevisionDiffResponse struct {
+	From    ContentRevisionResponse `json:"from"`
+	To      ContentRevisionResponse `json:"to"`
+	Changes map[string]diff.Change  `json:"changes"`
+}
+
+type RestoredRevisionResponse struct {
+	RevisionNumber int `json:"revision_number"`
+}
@@ -0,0 +1,12 @@
+package response
+
+type CorrectionResponse struct {
+	ID           int64         `json:"id"`
+	ContentID    int64         `json:"content_id"`
+	ContentTitle string        `json:"content_title,omitempty"`
+	CorrectedAt  string        `json:"corrected_at"`
+	WhatWasWrong string        `json:"what_was_wrong"`
+	WhatChanged  string        `json:"what_changed"`
+	Severity     string        `json:"severity"`
+	CreatedBy    *UserResponse `json:"created_by,omitempty"`
+}
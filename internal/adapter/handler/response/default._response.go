@@ -15,9 +15,13 @@ type DefaultSuccessReponse struct {
 	Pagination *PaginationResponse `json:"pagination,omitempty"`
 }
 
+// PaginationResponse describes the page of a list. Keyset paged lists have no
+// page number, they link their neighbours through the cursors instead.
 type PaginationResponse struct {
-	TotalRecords int `json:"total_records"`
-	Page         int `json:"page"`
-	PerPage      int `json:"per_page"`
-	TotalPages   int `json:"total_pages"`
+	TotalRecords int    `json:"total_records"`
+	Page         int    `json:"page,omitempty"`
+	PerPage      int    `json:"per_page"`
+	TotalPages   int    `json:"total_pages"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,44 @@
+package response
+
+type FactCheckResponse struct {
+	Claim         string        `json:"claim"`
+	Claimant      string        `json:"claimant"`
+	Rating        string        `json:"rating"`
+	RatingLabel   string        `json:"rating_label"`
+	Reviewer      *UserResponse `json:"reviewer"`
+	ReviewedAt    string        `json:"reviewed_at"`
+	EvidenceLinks []string      `json:"evidence_links"`
+}
+
+// ClaimReviewResponse is a schema.org ClaimReview, rendered as JSON-LD.
+type ClaimReviewResponse struct {
+	Context       string       `json:"@context"`
+	Type          string       `json:"@type"`
+	Url           string       `json:"url"`
+	Headline      string       `json:"headline,omitempty"`
+	ClaimReviewed string       `json:"claimReviewed"`
+	DatePublished string       `json:"datePublished"`
+	Author        SchemaThing  `json:"author"`
+	ReviewRating  SchemaRating `json:"reviewRating"`
+	ItemReviewed  SchemaClaim  `json:"itemReviewed"`
+	Citation      []string     `json:"citation,omitempty"`
+}
+
+type SchemaThing struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	Url  string `json:"url,omitempty"`
+}
+
+type SchemaRating struct {
+	Type          string `json:"@type"`
+	RatingValue   int    `json:"ratingValue,omitempty"`
+	BestRating    int    `json:"bestRating,omitempty"`
+	WorstRating   int    `json:"worstRating,omitempty"`
+	AlternateName string `json:"alternateName"`
+}
+
+type SchemaClaim struct {
+	Type   string      `json:"@type"`
+	Author SchemaThing `json:"author"`
+}
@@ -0,0 +1,12 @@
+package response
+
+type LockoutEventResponse struct {
+	ID          int64  `json:"id"`
+	Scope       string `json:"scope"`
+	Identifier  string `json:"identifier"`
+	UserID      *int64 `json:"user_id"`
+	IPAddress   string `json:"ip_address"`
+	Failures    int    `json:"failures"`
+	LockedUntil string `json:"locked_until"`
+	CreatedAt   string `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package response
+
+type ReportResponse struct {
+	ID            int64         `json:"id"`
+	ContentID     int64         `json:"content_id"`
+	ContentTitle  string        `json:"content_title,omitempty"`
+	Reason        string        `json:"reason"`
+	Details       string        `json:"details"`
+	Email         string        `json:"email,omitempty"`
+	IPAddress     string        `json:"ip_address,omitempty"`
+	UserAgent     string        `json:"user_agent,omitempty"`
+	Status        string        `json:"status"`
+	Moderator     *UserResponse `json:"moderator,omitempty"`
+	ModeratorNote string        `json:"moderator_note,omitempty"`
+	ResolvedAt    string        `json:"resolved_at,omitempty"`
+	CreatedAt     string        `json:"created_at"`
+}
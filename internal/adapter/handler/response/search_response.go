@@ -0,0 +1,24 @@
+package response
+
+type SearchResponse struct {
+	Contents []ContentResponse                `json:"contents"`
+	Facets   map[string][]SearchFacetResponse `json:"facets,omitempty"`
+}
+
+type SearchFacetResponse struct {
+	Term  string `json:"term"`
+	Count int    `json:"count"`
+}
+
+type SuggestionResponse struct {
+	Contents   []SuggestionItemResponse `json:"contents"`
+	Categories []SuggestionItemResponse `json:"categories"`
+	Tags       []SuggestionItemResponse `json:"tags"`
+}
+
+// SuggestionItemResponse is one suggestion, Title holds the name for tags.
+type SuggestionItemResponse struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
@@ -0,0 +1,9 @@
+package response
+
+type TagResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+	UsageCount int    `json:"usage_count"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
@@ -0,0 +1,11 @@
+package response
+
+type TwoFactorEnrollmentResponse struct {
+	Secret        string   `json:"secret"`
+	OtpauthUri    string   `json:"otpauth_uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorRolesResponse struct {
+	Roles []string `json:"roles"`
+}
@@ -1,7 +1,11 @@
 package response
 
 type UserResponse struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-}
\ No newline at end of file
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	Email            string `json:"email"`
+	Role             string `json:"role"`
+	IsActive         bool   `json:"is_active"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+	CreatedAt        string `json:"created_at,omitempty"`
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"slices"
+	"time"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// maxSuggestions caps the limit query parameter of Suggest.
+const maxSuggestions = 10
+
+type SearchHandler interface {
+	Search(c *fiber.Ctx) error
+	Suggest(c *fiber.Ctx) error
+}
+
+type searchHandler struct {
+	searchService     service.SearchService
+	suggestionService service.SuggestionService
+}
+
+// Search implements SearchHandler. Only published contents are searched,
+// category and tag take slugs, from and to accept RFC3339 timestamps or plain
+// dates.
+func (sh *searchHandler) Search(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] Search - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Page Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] Search - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Limit Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	orderBy := c.Query("orderBy", entity.SearchOrderRelevance)
+	if !slices.Contains([]string{entity.SearchOrderRelevance, entity.SearchOrderNewest, entity.SearchOrderOldest}, orderBy) {
+		code = "[HANDLER] Search - 3"
+		log.Errorw(code, "orderBy", orderBy)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Order, Use relevance, newest Or oldest"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	query := entity.SearchQueryEntity{
+		Query:        c.Query("q"),
+		CategorySlug: c.Query("category"),
+		TagSlug:      c.Query("tag"),
+		Status:       entity.ContentStatusPublished,
+		OrderBy:      orderBy,
+		Page:         page,
+		Limit:        limit,
+	}
+
+	if c.Query("authorID") != "" {
+		query.AuthorID, err = conv.StringToInt64(c.Query("authorID"))
+		if err != nil {
+			code = "[HANDLER] Search - 4"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Author ID"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	if c.Query("from") != "" {
+		from, err := parseQueryTime(c.Query("from"), false)
+		if err != nil {
+			code = "[HANDLER] Search - 5"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid From Date"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+		query.From = &from
+	}
+
+	if c.Query("to") != "" {
+		to, err := parseQueryTime(c.Query("to"), true)
+		if err != nil {
+			code = "[HANDLER] Search - 6"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid To Date"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+		query.To = &to
+	}
+
+	result, err := sh.searchService.Search(c.Context(), query)
+	if err != nil {
+		code = "[HANDLER] Search - 7"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respContents := []response.ContentResponse{}
+	for _, content := range result.Contents {
+		respContents = append(respContents, response.ContentResponse{
+			ID:           content.ID,
+			Title:        content.Title,
+			Slug:         content.Slug,
+			Excerpt:      content.Excerpt,
+			Image:        content.Image,
+			Tags:         toTagResponses(content.Tags),
+			Status:       content.Status,
+			IsValid:      content.IsValid,
+			CategoryID:   content.CategoryID,
+			CreatedByID:  content.CreatedByID,
+			PublishAt:    formatOptionalTime(content.PublishAt),
+			CreatedAt:    content.CreatedAt.Format(time.RFC3339),
+			CategoryName: content.Category.Title,
+			Author:       content.User.Name,
+			Highlight:    toContentHighlightResponse(content.Highlight),
+		})
+	}
+
+	var respFacets map[string][]response.SearchFacetResponse
+	if result.Facets != nil {
+		respFacets = map[string][]response.SearchFacetResponse{}
+		for name, terms := range result.Facets {
+			respTerms := []response.SearchFacetResponse{}
+			for _, term := range terms {
+				respTerms = append(respTerms, response.SearchFacetResponse{Term: term.Term, Count: term.Count})
+			}
+			respFacets[name] = respTerms
+		}
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = response.SearchResponse{
+		Contents: respContents,
+		Facets:   respFacets,
+	}
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(result.TotalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(result.TotalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// Suggest implements SearchHandler. It is called while the reader types, limit
+// applies to titles, categories and tags each and is capped at maxSuggestions.
+func (sh *searchHandler) Suggest(c *fiber.Ctx) error {
+	limit := 5
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] Suggest - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Limit Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+	limit = min(limit, maxSuggestions)
+
+	result, err := sh.suggestionService.GetSuggestions(c.Context(), c.Query("q"), limit)
+	if err != nil {
+		code = "[HANDLER] Suggest - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	resp := response.SuggestionResponse{
+		Contents:   []response.SuggestionItemResponse{},
+		Categories: []response.SuggestionItemResponse{},
+		Tags:       []response.SuggestionItemResponse{},
+	}
+	for _, content := range result.Contents {
+		resp.Contents = append(resp.Contents, response.SuggestionItemResponse{ID: content.ID, Title: content.Title, Slug: content.Slug})
+	}
+	for _, category := range result.Categories {
+		resp.Categories = append(resp.Categories, response.SuggestionItemResponse{ID: category.ID, Title: category.Title, Slug: category.Slug})
+	}
+	for _, tag := range result.Tags {
+		resp.Tags = append(resp.Tags, response.SuggestionItemResponse{ID: tag.ID, Title: tag.Name, Slug: tag.Slug})
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = resp
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func NewSearchHandler(searchService service.SearchService, suggestionService service.SuggestionService) SearchHandler {
+	return &searchHandler{
+		searchService:     searchService,
+		suggestionService: suggestionService,
+	}
+}
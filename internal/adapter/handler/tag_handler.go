@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"errors"
+	"time"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type TagHandler interface {
+	GetTags(c *fiber.Ctx) error
+	RenameTag(c *fiber.Ctx) error
+	MergeTags(c *fiber.Ctx) error
+	DeleteTag(c *fiber.Ctx) error
+}
+
+type tagHandler struct {
+	tagService service.TagService
+}
+
+// GetTags implements TagHandler.
+func (th *tagHandler) GetTags(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code = "[HANDLER] GetTags - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Page Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 20
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code = "[HANDLER] GetTags - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid Limit Number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.QueryString{
+		Limit:  limit,
+		Page:   page,
+		Search: c.Query("search"),
+	}
+
+	results, totalData, totalPages, err := th.tagService.GetTags(c.Context(), reqEntity)
+	if err != nil {
+		code = "[HANDLER] GetTags - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toTagResponses(results)
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// RenameTag implements TagHandler.
+func (th *tagHandler) RenameTag(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] RenameTag - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	tagID, err := conv.StringToInt64(c.Params("tagID"))
+	if err != nil {
+		code = "[HANDLER] RenameTag - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.TagRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] RenameTag - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] RenameTag - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	result, err := th.tagService.RenameTag(c.Context(), tagID, req.Name, actor)
+	if err != nil {
+		code = "[HANDLER] RenameTag - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Tag Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrInvalidTagName) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrTagNameTaken) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Tag Renamed"
+	defaultSuccessReponse.Data = toTagResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// MergeTags implements TagHandler.
+func (th *tagHandler) MergeTags(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] MergeTags - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	tagID, err := conv.StringToInt64(c.Params("tagID"))
+	if err != nil {
+		code = "[HANDLER] MergeTags - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.MergeTagRequest
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] MergeTags - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] MergeTags - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	result, err := th.tagService.MergeTags(c.Context(), tagID, req.TargetID, actor)
+	if err != nil {
+		code = "[HANDLER] MergeTags - 5"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Tag Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrMergeSameTag) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Tags Merged"
+	defaultSuccessReponse.Data = toTagResponse(*result)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// DeleteTag implements TagHandler.
+func (th *tagHandler) DeleteTag(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] DeleteTag - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	tagID, err := conv.StringToInt64(c.Params("tagID"))
+	if err != nil {
+		code = "[HANDLER] DeleteTag - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	actor := entity.UserEntity{
+		ID:   claims.UserID,
+		Role: claims.Role,
+	}
+
+	err = th.tagService.DeleteTag(c.Context(), tagID, actor)
+	if err != nil {
+		code = "[HANDLER] DeleteTag - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errorResp.Meta.Message = "Tag Not Found"
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Tag Deleted"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// toTagEntities reads the comma separated tags of a content request. Tags that
+// were left out stay nil, so an update leaves them alone.
+func toTagEntities(raw request.Optional[string]) []entity.TagEntity {
+	if !raw.Set {
+		return nil
+	}
+
+	tags := []entity.TagEntity{}
+	if raw.Value == nil {
+		return tags
+	}
+
+	for _, name := range conv.SplitTags(*raw.Value) {
+		tags = append(tags, entity.TagEntity{Name: name})
+	}
+
+	return tags
+}
+
+func toTagResponse(tag entity.TagEntity) response.TagResponse {
+	resp := response.TagResponse{
+		ID:         tag.ID,
+		Name:       tag.Name,
+		Slug:       tag.Slug,
+		UsageCount: tag.UsageCount,
+	}
+	if !tag.CreatedAt.IsZero() {
+		resp.CreatedAt = tag.CreatedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+func toTagResponses(tags []entity.TagEntity) []response.TagResponse {
+	resps := []response.TagResponse{}
+	for _, tag := range tags {
+		resps = append(resps, toTagResponse(tag))
+	}
+
+	return resps
+}
+
+func NewTagHandler(tagService service.TagService) TagHandler {
+	return &tagHandler{
+		tagService: tagService,
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"trustnews/internal/adapter/handler/request"
+	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	validatorLib "trustnews/lib/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type TwoFactorHandler interface {
+	Enroll(c *fiber.Ctx) error
+	Verify(c *fiber.Ctx) error
+	Disable(c *fiber.Ctx) error
+
+	// Admin
+	GetRequiredRoles(c *fiber.Ctx) error
+	SetRequiredRoles(c *fiber.Ctx) error
+}
+
+type twoFactorHandler struct {
+	twoFactorService service.TwoFactorService
+}
+
+// Enroll implements TwoFactorHandler.
+func (t *twoFactorHandler) Enroll(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] Enroll - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	result, err := t.twoFactorService.Enroll(c.Context(), int64(claims.UserID))
+	if err != nil {
+		code = "[HANDLER] Enroll - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrMfaAlreadyEnabled) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Scan The Code And Confirm It With A First Code"
+	defaultSuccessReponse.Data = response.TwoFactorEnrollmentResponse{
+		Secret:        result.Secret,
+		OtpauthUri:    result.OtpauthUri,
+		RecoveryCodes: result.RecoveryCodes,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// Verify implements TwoFactorHandler. It confirms a pending enrollment.
+func (t *twoFactorHandler) Verify(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] Verify - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	req := request.TwoFactorCodeRequest{}
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] Verify - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] Verify - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = t.twoFactorService.ConfirmEnrollment(c.Context(), int64(claims.UserID), req.Code)
+	if err != nil {
+		code = "[HANDLER] Verify - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrMfaAlreadyEnabled) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrMfaNotEnrolled) || errors.Is(err, service.ErrInvalidMfaCode) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Two-Factor Authentication Enabled"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// Disable implements TwoFactorHandler.
+func (t *twoFactorHandler) Disable(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	if claims.UserID == 0 {
+		code = "[HANDLER] Disable - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Unauthorized Access"
+
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResp)
+	}
+
+	req := request.TwoFactorCodeRequest{}
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] Disable - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] Disable - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = t.twoFactorService.Disable(c.Context(), int64(claims.UserID), req.Code)
+	if err != nil {
+		code = "[HANDLER] Disable - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrMfaRequiredForRole) {
+			return c.Status(fiber.StatusForbidden).JSON(errorResp)
+		}
+
+		if errors.Is(err, service.ErrMfaNotEnrolled) || errors.Is(err, service.ErrInvalidMfaCode) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Two-Factor Authentication Disabled"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetRequiredRoles implements TwoFactorHandler.
+func (t *twoFactorHandler) GetRequiredRoles(c *fiber.Ctx) error {
+	roles, err := t.twoFactorService.GetRequiredRoles(c.Context())
+	if err != nil {
+		code = "[HANDLER] GetRequiredRoles - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = response.TwoFactorRolesResponse{Roles: roles}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// SetRequiredRoles implements TwoFactorHandler.
+func (t *twoFactorHandler) SetRequiredRoles(c *fiber.Ctx) error {
+	req := request.TwoFactorRolesRequest{}
+	if err = c.BodyParser(&req); err != nil {
+		code = "[HANDLER] SetRequiredRoles - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(req); err != nil {
+		code = "[HANDLER] SetRequiredRoles - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = t.twoFactorService.SetRequiredRoles(c.Context(), req.Roles)
+	if err != nil {
+		code = "[HANDLER] SetRequiredRoles - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Two-Factor Policy Updated"
+	defaultSuccessReponse.Data = response.TwoFactorRolesResponse{Roles: req.Roles}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func NewTwoFactorHandler(twoFactorService service.TwoFactorService) TwoFactorHandler {
+	return &twoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
@@ -2,19 +2,30 @@ package handler
 
 import (
 	"errors"
+	"time"
 	"trustnews/internal/adapter/handler/request"
 	"trustnews/internal/adapter/handler/response"
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/service"
+	"trustnews/lib/conv"
 	validatorLib "trustnews/lib/validator"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
 )
 
 type UserHandler interface {
 	UpdatePassword(c *fiber.Ctx) error
 	GetUserByID(c *fiber.Ctx) error
+
+	// Admin
+	GetUsers(c *fiber.Ctx) error
+	GetUserDetail(c *fiber.Ctx) error
+	CreateUser(c *fiber.Ctx) error
+	UpdateUser(c *fiber.Ctx) error
+	DeactivateUser(c *fiber.Ctx) error
+	ReactivateUser(c *fiber.Ctx) error
 }
 
 type userHandler struct {
@@ -23,7 +34,7 @@ type userHandler struct {
 
 // GetUserByID implements UserHandler.
 func (u *userHandler) GetUserByID(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] GetUserByID - 1"
 		log.Errorw(code, err)
@@ -49,16 +60,20 @@ func (u *userHandler) GetUserByID(c *fiber.Ctx) error {
 		ID: user.ID,
 		Name: user.Name,
 		Email: user.Email,
+		Role: user.Role,
+		IsActive: user.IsActive,
+		TwoFactorEnabled: user.TwoFactorEnabled,
 	}
 
 	defaultSuccessReponse.Data = resp
+	defaultSuccessReponse.Pagination = nil
 
 	return c.JSON(defaultSuccessReponse)
 }
 
 // UpdatePassword implements UserHandler.
 func (u *userHandler) UpdatePassword(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*entity.JwtData)
+	claims := c.Locals("user").(*entity.Principal)
 	if claims.UserID == 0 {
 		code := "[HANDLER] UpdatePassword - 1"
 		log.Errorw(code, err)
@@ -114,6 +129,307 @@ func (u *userHandler) UpdatePassword(c *fiber.Ctx) error {
 	return c.JSON(defaultSuccessReponse)
 }
 
+// GetUsers implements UserHandler.
+func (u *userHandler) GetUsers(c *fiber.Ctx) error {
+	page := 1
+	if c.Query("page") != "" {
+		page, err = conv.StringToInt(c.Query("page"))
+		if err != nil || page < 1 {
+			code := "[HANDLER] GetUsers - 1"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid page number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	limit := 10
+	if c.Query("limit") != "" {
+		limit, err = conv.StringToInt(c.Query("limit"))
+		if err != nil || limit < 1 {
+			code := "[HANDLER] GetUsers - 2"
+			log.Errorw(code, err)
+			errorResp.Meta.Status = false
+			errorResp.Meta.Message = "Invalid limit number"
+
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+	}
+
+	reqEntity := entity.QueryString{
+		Limit:  limit,
+		Page:   page,
+		Search: c.Query("search"),
+	}
+
+	results, totalData, totalPages, err := u.userService.GetUsers(c.Context(), reqEntity)
+	if err != nil {
+		code := "[HANDLER] GetUsers - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	respUsers := []response.UserResponse{}
+	for _, user := range results {
+		respUsers = append(respUsers, toUserResponse(user))
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = respUsers
+	defaultSuccessReponse.Pagination = &response.PaginationResponse{
+		TotalRecords: int(totalData),
+		Page:         page,
+		PerPage:      limit,
+		TotalPages:   int(totalPages),
+	}
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// GetUserDetail implements UserHandler.
+func (u *userHandler) GetUserDetail(c *fiber.Ctx) error {
+	userID, err := conv.StringToInt64(c.Params("userID"))
+	if err != nil {
+		code := "[HANDLER] GetUserDetail - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	user, err := u.userService.GetUserByID(c.Context(), userID)
+	if err != nil {
+		code := "[HANDLER] GetUserDetail - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "Success"
+	defaultSuccessReponse.Data = toUserResponse(*user)
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// CreateUser implements UserHandler.
+func (u *userHandler) CreateUser(c *fiber.Ctx) error {
+	var req request.CreateUserRequest
+	if err = c.BodyParser(&req); err != nil {
+		code := "[HANDLER] CreateUser - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(&req); err != nil {
+		code := "[HANDLER] CreateUser - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.UserEntity{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+		Role:     req.Role,
+	}
+
+	id, err := u.userService.CreateUser(c.Context(), reqEntity)
+	if err != nil {
+		code := "[HANDLER] CreateUser - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrEmailAlreadyUsed) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "User Created Successfully"
+	defaultSuccessReponse.Data = map[string]interface{}{
+		"id": id,
+	}
+	defaultSuccessReponse.Pagination = nil
+
+	return c.Status(fiber.StatusCreated).JSON(defaultSuccessReponse)
+}
+
+// UpdateUser implements UserHandler.
+func (u *userHandler) UpdateUser(c *fiber.Ctx) error {
+	userID, err := conv.StringToInt64(c.Params("userID"))
+	if err != nil {
+		code := "[HANDLER] UpdateUser - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	var req request.UpdateUserRequest
+	if err = c.BodyParser(&req); err != nil {
+		code := "[HANDLER] UpdateUser - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = "Invalid Request Body"
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	if err = validatorLib.ValidateStruct(&req); err != nil {
+		code := "[HANDLER] UpdateUser - 3"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	reqEntity := entity.UserEntity{
+		ID:    userID,
+		Name:  req.Name,
+		Email: req.Email,
+		Role:  req.Role,
+	}
+
+	err = u.userService.UpdateUser(c.Context(), reqEntity)
+	if err != nil {
+		code := "[HANDLER] UpdateUser - 4"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrEmailAlreadyUsed) {
+			return c.Status(fiber.StatusConflict).JSON(errorResp)
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "User Updated Successfully"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// DeactivateUser implements UserHandler.
+func (u *userHandler) DeactivateUser(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*entity.Principal)
+	userID, err := conv.StringToInt64(c.Params("userID"))
+	if err != nil {
+		code := "[HANDLER] DeactivateUser - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = u.userService.DeactivateUser(c.Context(), userID, int64(claims.UserID))
+	if err != nil {
+		code := "[HANDLER] DeactivateUser - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, service.ErrSelfDeactivation) {
+			return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "User Deactivated Successfully"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+// ReactivateUser implements UserHandler.
+func (u *userHandler) ReactivateUser(c *fiber.Ctx) error {
+	userID, err := conv.StringToInt64(c.Params("userID"))
+	if err != nil {
+		code := "[HANDLER] ReactivateUser - 1"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		return c.Status(fiber.StatusBadRequest).JSON(errorResp)
+	}
+
+	err = u.userService.ReactivateUser(c.Context(), userID)
+	if err != nil {
+		code := "[HANDLER] ReactivateUser - 2"
+		log.Errorw(code, err)
+		errorResp.Meta.Status = false
+		errorResp.Meta.Message = err.Error()
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(errorResp)
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResp)
+	}
+
+	defaultSuccessReponse.Meta.Status = true
+	defaultSuccessReponse.Meta.Message = "User Reactivated Successfully"
+	defaultSuccessReponse.Data = nil
+	defaultSuccessReponse.Pagination = nil
+
+	return c.JSON(defaultSuccessReponse)
+}
+
+func toUserResponse(user entity.UserEntity) response.UserResponse {
+	resp := response.UserResponse{
+		ID:       user.ID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Role:     user.Role,
+		IsActive: user.IsActive,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+	}
+	if !user.CreatedAt.IsZero() {
+		resp.CreatedAt = user.CreatedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
+
 func NewUserHandler(userService service.UserService) UserHandler {
 	return &userHandler{userService: userService}
 }
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"trustnews/config"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// logMailer is meant for local development, mails are appended to a file
+// (or written to the application log when no path is configured).
+type logMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (l *logMailer) Send(ctx context.Context, mail entity.MailEntity) error {
+	if l.path == "" {
+		log.Infow("[MAILER] mail", "to", mail.To, "subject", mail.Subject, "body", mail.Body)
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		code = "[MAILER] LogSend - 1"
+		log.Errorw(code, err)
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n",
+		time.Now().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+	if err != nil {
+		code = "[MAILER] LogSend - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func NewLogMailer(cfg *config.Config) Mailer {
+	return &logMailer{path: cfg.Mail.LogPath}
+}
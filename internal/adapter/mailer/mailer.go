@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"context"
+	"trustnews/config"
+	"trustnews/internal/core/domain/entity"
+)
+
+var code string
+
+type Mailer interface {
+	Send(ctx context.Context, mail entity.MailEntity) error
+}
+
+// NewMailer picks the implementation from MAIL_DRIVER. Anything other than
+// "smtp" falls back to the log mailer so local setups never send real mail.
+func NewMailer(cfg *config.Config) Mailer {
+	if cfg.Mail.Driver == "smtp" {
+		return NewSmtpMailer(cfg)
+	}
+
+	return NewLogMailer(cfg)
+}
@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"trustnews/config"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type smtpMailer struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func (s *smtpMailer) Send(ctx context.Context, mail entity.MailEntity) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + mail.To,
+		"Subject: " + mail.Subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"",
+		mail.Body,
+	}, "\r\n")
+
+	err := smtp.SendMail(s.addr, auth, s.from, []string{mail.To}, []byte(msg))
+	if err != nil {
+		code = "[MAILER] SmtpSend - 1"
+		log.Errorw(code, err)
+		return fmt.Errorf("send mail to %s: %w", mail.To, err)
+	}
+
+	return nil
+}
+
+func NewSmtpMailer(cfg *config.Config) Mailer {
+	return &smtpMailer{
+		addr:     net.JoinHostPort(cfg.Mail.Host, cfg.Mail.Port),
+		host:     cfg.Mail.Host,
+		from:     cfg.Mail.From,
+		username: cfg.Mail.Username,
+		password: cfg.Mail.Password,
+	}
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// apiKeyTouchInterval limits how often last_used_at is written for a busy key.
+const apiKeyTouchInterval = time.Minute
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, req entity.ApiKeyEntity) (int64, error)
+	GetApiKeys(ctx context.Context, query entity.QueryString) ([]entity.ApiKeyEntity, int64, int64, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKeyEntity, error)
+	RevokeApiKey(ctx context.Context, id int64) error
+	TouchApiKey(ctx context.Context, id int64) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// CreateApiKey implements ApiKeyRepository.
+func (a *apiKeyRepository) CreateApiKey(ctx context.Context, req entity.ApiKeyEntity) (int64, error) {
+	modelKey := model.ApiKey{
+		Name:        req.Name,
+		KeyPrefix:   req.KeyPrefix,
+		KeyHash:     req.KeyHash,
+		Scopes:      strings.Join(req.Scopes, ","),
+		UserID:      req.UserID,
+		CreatedByID: req.CreatedByID,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   time.Now(),
+	}
+
+	err = a.db.Omit("User").Create(&modelKey).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateApiKey - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return modelKey.ID, nil
+}
+
+// GetApiKeys implements ApiKeyRepository.
+func (a *apiKeyRepository) GetApiKeys(ctx context.Context, query entity.QueryString) ([]entity.ApiKeyEntity, int64, int64, error) {
+	var modelKeys []model.ApiKey
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := a.db.Model(&model.ApiKey{}).Preload("User")
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("name ilike ? OR key_prefix ilike ?", "%"+query.Search+"%", "%"+query.Search+"%")
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetApiKeys - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("created_at DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelKeys).Error
+	if err != nil {
+		code = "[REPOSITORY] GetApiKeys - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.ApiKeyEntity{}
+	for _, val := range modelKeys {
+		resps = append(resps, toApiKeyEntity(val))
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// GetApiKeyByHash implements ApiKeyRepository.
+func (a *apiKeyRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*entity.ApiKeyEntity, error) {
+	var modelKey model.ApiKey
+	err = a.db.Preload("User").Where("key_hash = ?", keyHash).First(&modelKey).Error
+	if err != nil {
+		code = "[REPOSITORY] GetApiKeyByHash - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toApiKeyEntity(modelKey)
+	return &resp, nil
+}
+
+// RevokeApiKey implements ApiKeyRepository.
+func (a *apiKeyRepository) RevokeApiKey(ctx context.Context, id int64) error {
+	result := a.db.Model(&model.ApiKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		code = "[REPOSITORY] RevokeApiKey - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// TouchApiKey implements ApiKeyRepository.
+func (a *apiKeyRepository) TouchApiKey(ctx context.Context, id int64) error {
+	now := time.Now()
+	err = a.db.Model(&model.ApiKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-apiKeyTouchInterval)).
+		Update("last_used_at", now).Error
+	if err != nil {
+		code = "[REPOSITORY] TouchApiKey - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func toApiKeyEntity(modelKey model.ApiKey) entity.ApiKeyEntity {
+	scopes := []string{}
+	if modelKey.Scopes != "" {
+		scopes = strings.Split(modelKey.Scopes, ",")
+	}
+
+	return entity.ApiKeyEntity{
+		ID:        modelKey.ID,
+		Name:      modelKey.Name,
+		KeyPrefix: modelKey.KeyPrefix,
+		Scopes:    scopes,
+		UserID:    modelKey.UserID,
+		User: entity.UserEntity{
+			ID:       modelKey.User.ID,
+			Name:     modelKey.User.Name,
+			Email:    modelKey.User.Email,
+			Role:     modelKey.User.Role,
+			IsActive: modelKey.User.IsActive,
+		},
+		CreatedByID: modelKey.CreatedByID,
+		ExpiresAt:   modelKey.ExpiresAt,
+		LastUsedAt:  modelKey.LastUsedAt,
+		RevokedAt:   modelKey.RevokedAt,
+		CreatedAt:   modelKey.CreatedAt,
+	}
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	return &apiKeyRepository{db: db}
+}
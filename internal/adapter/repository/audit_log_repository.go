@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+	"trustnews/lib/diff"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	CreateAuditLog(ctx context.Context, req entity.AuditLogEntity) error
+	GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]entity.AuditLogEntity, int64, int64, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// CreateAuditLog implements AuditLogRepository.
+func (a *auditLogRepository) CreateAuditLog(ctx context.Context, req entity.AuditLogEntity) error {
+	changes, err := json.Marshal(req.Changes)
+	if err != nil {
+		code = "[REPOSITORY] CreateAuditLog - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	modelLog := model.AuditLog{
+		ActorID:    req.ActorID,
+		ApiKeyID:   req.ApiKeyID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Changes:    string(changes),
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+		CreatedAt:  time.Now(),
+	}
+
+	err = a.db.Omit("Actor").Create(&modelLog).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateAuditLog - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAuditLogs implements AuditLogRepository.
+func (a *auditLogRepository) GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]entity.AuditLogEntity, int64, int64, error) {
+	var modelLogs []model.AuditLog
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := a.db.Model(&model.AuditLog{}).Preload("Actor")
+	if query.ActorID > 0 {
+		sqlMain = sqlMain.Where("actor_id = ?", query.ActorID)
+	}
+
+	if query.Action != "" {
+		sqlMain = sqlMain.Where("action = ?", query.Action)
+	}
+
+	if query.EntityType != "" {
+		sqlMain = sqlMain.Where("entity_type = ?", query.EntityType)
+	}
+
+	if query.EntityID > 0 {
+		sqlMain = sqlMain.Where("entity_id = ?", query.EntityID)
+	}
+
+	if query.From != nil {
+		sqlMain = sqlMain.Where("created_at >= ?", *query.From)
+	}
+
+	if query.To != nil {
+		sqlMain = sqlMain.Where("created_at <= ?", *query.To)
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetAuditLogs - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("created_at DESC, id DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelLogs).Error
+	if err != nil {
+		code = "[REPOSITORY] GetAuditLogs - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.AuditLogEntity{}
+	for _, val := range modelLogs {
+		changes := map[string]diff.Change{}
+		if err := json.Unmarshal([]byte(val.Changes), &changes); err != nil {
+			code = "[REPOSITORY] GetAuditLogs - 3"
+			log.Errorw(code, err)
+		}
+
+		resp := entity.AuditLogEntity{
+			ID:         val.ID,
+			ActorID:    val.ActorID,
+			ApiKeyID:   val.ApiKeyID,
+			Action:     val.Action,
+			EntityType: val.EntityType,
+			EntityID:   val.EntityID,
+			Changes:    changes,
+			IPAddress:  val.IPAddress,
+			UserAgent:  val.UserAgent,
+			CreatedAt:  val.CreatedAt,
+		}
+		if val.Actor != nil {
+			resp.Actor = &entity.UserEntity{
+				ID:    val.Actor.ID,
+				Name:  val.Actor.Name,
+				Email: val.Actor.Email,
+				Role:  val.Actor.Role,
+			}
+		}
+
+		resps = append(resps, resp)
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
@@ -4,9 +4,12 @@ import (
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/domain/model"
 	"context"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var err error 
@@ -14,16 +17,30 @@ var code string
 
 type AuthRepository interface {
 	GetUserByEmail(ctx context.Context, req entity.LoginRequest)(*entity.UserEntity, error)
+	GetUserByID(ctx context.Context, id int64)(*entity.UserEntity, error)
+
+	CreateRefreshToken(ctx context.Context, req entity.RefreshTokenEntity) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string)(*entity.RefreshTokenEntity, error)
+	GetRefreshTokenByAccessTokenID(ctx context.Context, jti string)(*entity.RefreshTokenEntity, error)
+	MarkRefreshTokenUsed(ctx context.Context, id int64)(bool, error)
+	RevokeTokenFamily(ctx context.Context, familyID string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string)(bool, error)
+
+	CreatePasswordReset(ctx context.Context, req entity.PasswordResetEntity) error
+	GetPasswordResetByHash(ctx context.Context, tokenHash string)(*entity.PasswordResetEntity, error)
+	ConsumePasswordReset(ctx context.Context, id int64, userID int64, newPassword string)(bool, error)
 }
 
 type authRepository struct {
 	db *gorm.DB
 }
 
+// GetUserByEmail ignores the case of the email, matching idx_users_email_lower.
 func (a *authRepository) GetUserByEmail(ctx context.Context, req entity.LoginRequest) (*entity.UserEntity, error) {
 	var modelUser model.User
 
-	err = a.db.Where("email = ?", req.Email).First(&modelUser).Error
+	err = a.db.Where("lower(email) = lower(?)", strings.TrimSpace(req.Email)).First(&modelUser).Error
 	if err != nil {
 		code = "[REPOSITORY] GetUserByEmail - 1"
 		log.Errorw(code, err)
@@ -35,11 +52,287 @@ func (a *authRepository) GetUserByEmail(ctx context.Context, req entity.LoginReq
 		Name: modelUser.Name,
 		Email: modelUser.Email,
 		Password: modelUser.Password,
+		Role: modelUser.Role,
+		IsActive: modelUser.IsActive,
+		TwoFactorEnabled: modelUser.TotpEnabled,
+	}
+
+	return &resp, nil
+}
+
+func (a *authRepository) GetUserByID(ctx context.Context, id int64) (*entity.UserEntity, error) {
+	var modelUser model.User
+
+	err = a.db.Where("id = ?", id).First(&modelUser).Error
+	if err != nil {
+		code = "[REPOSITORY] GetUserByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := entity.UserEntity{
+		ID: modelUser.ID,
+		Name: modelUser.Name,
+		Email: modelUser.Email,
+		Role: modelUser.Role,
+		IsActive: modelUser.IsActive,
+		TwoFactorEnabled: modelUser.TotpEnabled,
+		SessionsRevokedAt: modelUser.SessionsRevokedAt,
 	}
 
 	return &resp, nil
 }
 
+func (a *authRepository) CreateRefreshToken(ctx context.Context, req entity.RefreshTokenEntity) error {
+	modelToken := model.RefreshToken{
+		UserID: req.UserID,
+		FamilyID: req.FamilyID,
+		TokenHash: req.TokenHash,
+		AccessTokenID: req.AccessTokenID,
+		AccessExpiresAt: req.AccessExpiresAt,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	err = a.db.Create(&modelToken).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateRefreshToken - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *authRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshTokenEntity, error) {
+	var modelToken model.RefreshToken
+
+	err = a.db.Where("token_hash = ?", tokenHash).First(&modelToken).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRefreshTokenByHash - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(modelToken), nil
+}
+
+func (a *authRepository) GetRefreshTokenByAccessTokenID(ctx context.Context, jti string) (*entity.RefreshTokenEntity, error) {
+	var modelToken model.RefreshToken
+
+	err = a.db.Where("access_token_id = ?", jti).First(&modelToken).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRefreshTokenByAccessTokenID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(modelToken), nil
+}
+
+// MarkRefreshTokenUsed flags the token as consumed. It returns false when the
+// token was already used or revoked, so two concurrent refreshes cannot both win.
+func (a *authRepository) MarkRefreshTokenUsed(ctx context.Context, id int64) (bool, error) {
+	result := a.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		code = "[REPOSITORY] MarkRefreshTokenUsed - 1"
+		log.Errorw(code, result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeTokenFamily revokes every refresh token of the family together with the
+// access tokens that were issued alongside them and have not expired yet.
+func (a *authRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		var modelTokens []model.RefreshToken
+		err := tx.Where("family_id = ?", familyID).Find(&modelTokens).Error
+		if err != nil {
+			code = "[REPOSITORY] RevokeTokenFamily - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			code = "[REPOSITORY] RevokeTokenFamily - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		revoked := []model.RevokedToken{}
+		for _, val := range modelTokens {
+			if val.AccessExpiresAt.After(now) {
+				revoked = append(revoked, model.RevokedToken{
+					Jti: val.AccessTokenID,
+					ExpiresAt: val.AccessExpiresAt,
+				})
+			}
+		}
+
+		if len(revoked) == 0 {
+			return nil
+		}
+
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error
+		if err != nil {
+			code = "[REPOSITORY] RevokeTokenFamily - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (a *authRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	modelRevoked := model.RevokedToken{
+		Jti: jti,
+		ExpiresAt: expiresAt,
+	}
+
+	err = a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&modelRevoked).Error
+	if err != nil {
+		code = "[REPOSITORY] RevokeAccessToken - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *authRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+
+	err = a.db.Model(&model.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	if err != nil {
+		code = "[REPOSITORY] IsAccessTokenRevoked - 1"
+		log.Errorw(code, err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CreatePasswordReset stores a new reset token and invalidates the ones the
+// user requested before, so only the latest mail works.
+func (a *authRepository) CreatePasswordReset(ctx context.Context, req entity.PasswordResetEntity) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.PasswordReset{}).
+			Where("user_id = ? AND used_at IS NULL", req.UserID).
+			Update("used_at", time.Now()).Error
+		if err != nil {
+			code = "[REPOSITORY] CreatePasswordReset - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		modelReset := model.PasswordReset{
+			UserID: req.UserID,
+			TokenHash: req.TokenHash,
+			ExpiresAt: req.ExpiresAt,
+		}
+
+		err = tx.Create(&modelReset).Error
+		if err != nil {
+			code = "[REPOSITORY] CreatePasswordReset - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (a *authRepository) GetPasswordResetByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetEntity, error) {
+	var modelReset model.PasswordReset
+
+	err = a.db.Where("token_hash = ?", tokenHash).First(&modelReset).Error
+	if err != nil {
+		code = "[REPOSITORY] GetPasswordResetByHash - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.PasswordResetEntity{
+		ID: modelReset.ID,
+		UserID: modelReset.UserID,
+		TokenHash: modelReset.TokenHash,
+		ExpiresAt: modelReset.ExpiresAt,
+		UsedAt: modelReset.UsedAt,
+	}, nil
+}
+
+// ConsumePasswordReset marks the token as used, stores the new password hash
+// and logs the user out everywhere. It returns false when the token was
+// already consumed by a concurrent request.
+func (a *authRepository) ConsumePasswordReset(ctx context.Context, id int64, userID int64, newPassword string) (bool, error) {
+	consumed := false
+	now := time.Now()
+
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", now)
+		if result.Error != nil {
+			code = "[REPOSITORY] ConsumePasswordReset - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// access tokens cannot be revoked one by one, the ones issued before
+		// now are refused by the middleware
+		err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password":            newPassword,
+			"sessions_revoked_at": now,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] ConsumePasswordReset - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+		if err != nil {
+			code = "[REPOSITORY] ConsumePasswordReset - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		consumed = true
+		return nil
+	})
+
+	return consumed, err
+}
+
+func toRefreshTokenEntity(modelToken model.RefreshToken) *entity.RefreshTokenEntity {
+	return &entity.RefreshTokenEntity{
+		ID: modelToken.ID,
+		UserID: modelToken.UserID,
+		FamilyID: modelToken.FamilyID,
+		TokenHash: modelToken.TokenHash,
+		AccessTokenID: modelToken.AccessTokenID,
+		AccessExpiresAt: modelToken.AccessExpiresAt,
+		ExpiresAt: modelToken.ExpiresAt,
+		UsedAt: modelToken.UsedAt,
+		RevokedAt: modelToken.RevokedAt,
+	}
+}
+
 func NewAuthRepository(db *gorm.DB) AuthRepository {
 	return &authRepository{db: db}
 }
\ No newline at end of file
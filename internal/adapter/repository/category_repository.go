@@ -3,7 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
-	"fmt"
+	"slices"
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/domain/model"
 
@@ -11,10 +11,17 @@ import (
 	"gorm.io/gorm"
 )
 
+var ErrCategoryCycle = errors.New("A Category Cannot Be Placed Below Itself Or One Of Its Subcategories")
+
+// categoryTreeLockKey serializes moves within the category tree, two moves
+// checked at the same time could otherwise still close a cycle.
+const categoryTreeLockKey = 7341002
+
 type CategoryRepository interface {
 	GetCategories(ctx context.Context)([]entity.CategoryEntity, error)
 	GetCategoryByID(ctx context.Context, id int64)(*entity.CategoryEntity, error)
-	CreateCategory(ctx context.Context, req entity.CategoryEntity) error
+	GetCategoryBySlug(ctx context.Context, slug string)(*entity.CategoryEntity, error)
+	CreateCategory(ctx context.Context, req entity.CategoryEntity) (int64, error)
 	EditCategoryByID(ctx context.Context, req entity.CategoryEntity) error
 	DeleteCategory(ctx context.Context, id int64) error
 }
@@ -23,21 +30,22 @@ type categoryRepository struct {
 	db *gorm.DB
 }
 
-func (c *categoryRepository) CreateCategory(ctx context.Context, req entity.CategoryEntity) error {
-	var countSlug int64
-	err = c.db.Table("categories").Where("slug = ?", req.Slug).Count(&countSlug).Error
+func (c *categoryRepository) CreateCategory(ctx context.Context, req entity.CategoryEntity) (int64, error) {
+	slug, err := uniqueSlug(c.db, categorySlugs, req.Slug, 0)
 	if err != nil {
 		code = "[REPOSITORY] CreateCategory - 1"
 		log.Errorw(code, err)
-		return err
+		return 0, err
 	}
 
-	countSlug = countSlug + 1
-	slug := fmt.Sprintf("%s-%d", req.Slug, countSlug)
-
 	modelCategory := model.Category{
 		Title: req.Title,
 		Slug: slug,
+		ParentID: req.ParentID,
+		SortOrder: req.SortOrder,
+		Description: req.Description,
+		SeoTitle: req.SeoTitle,
+		SeoDescription: req.SeoDescription,
 		CreatedByID: req.User.ID,
 	}
 
@@ -45,10 +53,10 @@ func (c *categoryRepository) CreateCategory(ctx context.Context, req entity.Cate
 	if err != nil {
 		code = "[REPOSITORY] CreateCategory - 2"
 		log.Errorw(code, err)
-		return err 
+		return 0, err 
 	}
 
-	return nil
+	return modelCategory.ID, nil
 }
 
 func (c *categoryRepository) DeleteCategory(ctx context.Context, id int64) error {
@@ -64,40 +72,20 @@ func (c *categoryRepository) DeleteCategory(ctx context.Context, id int64) error
 		return errors.New("Cannot Delete a Category That Has Associated Contents")
 	}
 
-	err = c.db.Where("id = ?", id).Delete(&model.Category{}).Error
+	err = c.db.Table("categories").Where("parent_id = ?", id).Count(&count).Error
 	if err != nil {
 		code = "[REPOSITORY] DeleteCategory - 2"
 		log.Errorw(code, err)
 		return err
 	}
 
-	return nil
-}
-
-func (c *categoryRepository) EditCategoryByID(ctx context.Context, req entity.CategoryEntity) error {
-	var countSlug int64
-	err = c.db.Table("categories").Where("slug = ?", req.Slug).Count(&countSlug).Error
-	if err != nil {
-		code = "[REPOSITORY] EditCategoryByID - 1"
-		log.Errorw(code, err)
-		return err
-	}
-
-	countSlug = countSlug + 1
-	slug := req.Slug
-	if countSlug == 0 {
-		slug = fmt.Sprintf("%s-%d", req.Slug, countSlug)
-	}
-
-	modelCategory := model.Category{
-		Title: req.Title,
-		Slug: slug,
-		CreatedByID: req.User.ID,
+	if count > 0 {
+		return errors.New("Cannot Delete a Category That Has Subcategories")
 	}
 
-	err = c.db.Where("id = ?", req.ID).Updates(&modelCategory).Error
+	err = c.db.Where("id = ?", id).Delete(&model.Category{}).Error
 	if err != nil {
-		code = "[REPOSITORY] EditCategoryByID - 2"
+		code = "[REPOSITORY] DeleteCategory - 3"
 		log.Errorw(code, err)
 		return err
 	}
@@ -105,10 +93,82 @@ func (c *categoryRepository) EditCategoryByID(ctx context.Context, req entity.Ca
 	return nil
 }
 
+// EditCategoryByID implements CategoryRepository. Moving a category below
+// itself or one of its descendants fails with ErrCategoryCycle.
+func (c *categoryRepository) EditCategoryByID(ctx context.Context, req entity.CategoryEntity) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Category
+		err := tx.Select("slug").Where("id = ?", req.ID).First(&current).Error
+		if err != nil {
+			code = "[REPOSITORY] EditCategoryByID - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if req.ParentID != nil {
+			err = tx.Exec("SELECT pg_advisory_xact_lock(?)", categoryTreeLockKey).Error
+			if err != nil {
+				code = "[REPOSITORY] EditCategoryByID - 2"
+				log.Errorw(code, err)
+				return err
+			}
+
+			var ancestors []int64
+			err = tx.Raw(`WITH RECURSIVE ancestors AS (
+					SELECT id, parent_id FROM categories WHERE id = ?
+					UNION ALL
+					SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+				) SELECT id FROM ancestors`, *req.ParentID).Scan(&ancestors).Error
+			if err != nil {
+				code = "[REPOSITORY] EditCategoryByID - 3"
+				log.Errorw(code, err)
+				return err
+			}
+
+			if slices.Contains(ancestors, req.ID) {
+				return ErrCategoryCycle
+			}
+		}
+
+		slug, err := uniqueSlug(tx, categorySlugs, req.Slug, req.ID)
+		if err != nil {
+			code = "[REPOSITORY] EditCategoryByID - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		// a map so the parent can be cleared and the sort order set to 0
+		err = tx.Model(&model.Category{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+			"title":           req.Title,
+			"slug":            slug,
+			"parent_id":       req.ParentID,
+			"sort_order":      req.SortOrder,
+			"description":     req.Description,
+			"seo_title":       req.SeoTitle,
+			"seo_description": req.SeoDescription,
+			"created_by_id":   req.User.ID,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] EditCategoryByID - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recordSlugChange(tx, categorySlugs, req.ID, current.Slug, slug)
+		if err != nil {
+			code = "[REPOSITORY] EditCategoryByID - 6"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
 func (c *categoryRepository) GetCategories(ctx context.Context) ([]entity.CategoryEntity, error) {
 	var modelCategories []model.Category
 
-	err = c.db.Order("created_at DESC").Preload("User").Find(&modelCategories).Error
+	err = c.db.Order("sort_order ASC, title ASC").Preload("User").Find(&modelCategories).Error
 	if err != nil {
 		code = "[REPOSITORY] GetCategories - 1"
 		log.Errorw(code, err)
@@ -124,17 +184,7 @@ func (c *categoryRepository) GetCategories(ctx context.Context) ([]entity.Catego
 
 	var  resps []entity.CategoryEntity
 	for _, val := range modelCategories {
-		resps = append(resps, entity.CategoryEntity{
-			ID: val.ID,
-			Title: val.Title,
-			Slug: val.Slug,
-			User: entity.UserEntity{
-				ID: val.User.ID,
-				Name: val.User.Name,
-				Email: val.User.Email,
-				Password: val.User.Password,
-			},
-		})
+		resps = append(resps, toCategoryEntity(val))
 	}
 
 	return resps, nil
@@ -149,16 +199,48 @@ func (c *categoryRepository) GetCategoryByID(ctx context.Context, id int64) (*en
 		return nil, err
 	}
 
-	return &entity.CategoryEntity{
+	resp := toCategoryEntity(modelCategory)
+	return &resp, nil
+}
+
+// GetCategoryBySlug implements CategoryRepository. Old slugs resolve to the
+// category as well, callers compare the returned Slug to spot them.
+func (c *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.CategoryEntity, error) {
+	id, err := slugOwner(c.db, categorySlugs, slug)
+	if err != nil {
+		code = "[REPOSITORY] GetCategoryBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return c.GetCategoryByID(ctx, id)
+}
+
+// categorySubtree selects the ids of a category and all of its descendants.
+func categorySubtree(db *gorm.DB, id int64) *gorm.DB {
+	return db.Raw(`WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		) SELECT id FROM subtree`, id)
+}
+
+func toCategoryEntity(modelCategory model.Category) entity.CategoryEntity {
+	return entity.CategoryEntity{
 		ID: modelCategory.ID,
 		Title: modelCategory.Title,
 		Slug: modelCategory.Slug,
+		ParentID: modelCategory.ParentID,
+		SortOrder: modelCategory.SortOrder,
+		Description: modelCategory.Description,
+		SeoTitle: modelCategory.SeoTitle,
+		SeoDescription: modelCategory.SeoDescription,
 		User: entity.UserEntity{
 			ID: modelCategory.User.ID,
 			Name: modelCategory.User.Name,
 			Email: modelCategory.User.Email,
 		},
-	}, nil
+	}
 }
 
 func NewCategoryRepository(db *gorm.DB) CategoryRepository {
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type ContentCorrectionRepository interface {
+	CreateCorrection(ctx context.Context, req entity.ContentCorrectionEntity) (int64, error)
+	GetCorrectionByID(ctx context.Context, contentID, id int64) (*entity.ContentCorrectionEntity, error)
+	GetCorrectionsByContentID(ctx context.Context, contentID int64) ([]entity.ContentCorrectionEntity, error)
+	GetPublishedCorrections(ctx context.Context, query entity.QueryString) ([]entity.ContentCorrectionEntity, int64, int64, error)
+	DeleteCorrection(ctx context.Context, contentID, id int64) error
+}
+
+type contentCorrectionRepository struct {
+	db *gorm.DB
+}
+
+// CreateCorrection implements ContentCorrectionRepository. The content's
+// updated_at is bumped in the same transaction, so readers see it changed.
+func (c *contentCorrectionRepository) CreateCorrection(ctx context.Context, req entity.ContentCorrectionEntity) (int64, error) {
+	modelCorrection := model.ContentCorrection{
+		ContentID:    req.ContentID,
+		CorrectedAt:  req.CorrectedAt,
+		WhatWasWrong: req.WhatWasWrong,
+		WhatChanged:  req.WhatChanged,
+		Severity:     req.Severity,
+		CreatedAt:    time.Now(),
+	}
+	if req.CreatedByID > 0 {
+		modelCorrection.CreatedByID = &req.CreatedByID
+	}
+
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Content", "CreatedBy").Create(&modelCorrection).Error
+		if err != nil {
+			code = "[REPOSITORY] CreateCorrection - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.Content{}).Where("id = ?", req.ContentID).UpdateColumn("updated_at", modelCorrection.CreatedAt).Error
+		if err != nil {
+			code = "[REPOSITORY] CreateCorrection - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return modelCorrection.ID, nil
+}
+
+// GetCorrectionByID implements ContentCorrectionRepository.
+func (c *contentCorrectionRepository) GetCorrectionByID(ctx context.Context, contentID, id int64) (*entity.ContentCorrectionEntity, error) {
+	var modelCorrection model.ContentCorrection
+	err = c.db.Preload("CreatedBy").Where("id = ? AND content_id = ?", id, contentID).First(&modelCorrection).Error
+	if err != nil {
+		code = "[REPOSITORY] GetCorrectionByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toContentCorrectionEntity(modelCorrection)
+	return &resp, nil
+}
+
+// GetCorrectionsByContentID implements ContentCorrectionRepository.
+func (c *contentCorrectionRepository) GetCorrectionsByContentID(ctx context.Context, contentID int64) ([]entity.ContentCorrectionEntity, error) {
+	var modelCorrections []model.ContentCorrection
+	err = c.db.Preload("CreatedBy").
+		Where("content_id = ?", contentID).
+		Order("corrected_at DESC, id DESC").
+		Find(&modelCorrections).Error
+	if err != nil {
+		code = "[REPOSITORY] GetCorrectionsByContentID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resps := []entity.ContentCorrectionEntity{}
+	for _, val := range modelCorrections {
+		resps = append(resps, toContentCorrectionEntity(val))
+	}
+
+	return resps, nil
+}
+
+// GetPublishedCorrections implements ContentCorrectionRepository. Corrections
+// of contents that are not published are left out.
+func (c *contentCorrectionRepository) GetPublishedCorrections(ctx context.Context, query entity.QueryString) ([]entity.ContentCorrectionEntity, int64, int64, error) {
+	var modelCorrections []model.ContentCorrection
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := c.db.Model(&model.ContentCorrection{}).
+		Preload("Content").
+		Joins("JOIN contents ON contents.id = content_corrections.content_id").
+		Where("contents.status = ?", entity.ContentStatusPublished)
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetPublishedCorrections - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("content_corrections.corrected_at DESC, content_corrections.id DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelCorrections).Error
+	if err != nil {
+		code = "[REPOSITORY] GetPublishedCorrections - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.ContentCorrectionEntity{}
+	for _, val := range modelCorrections {
+		resps = append(resps, toContentCorrectionEntity(val))
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// DeleteCorrection implements ContentCorrectionRepository.
+func (c *contentCorrectionRepository) DeleteCorrection(ctx context.Context, contentID, id int64) error {
+	result := c.db.Where("id = ? AND content_id = ?", id, contentID).Delete(&model.ContentCorrection{})
+	if result.Error != nil {
+		code = "[REPOSITORY] DeleteCorrection - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func toContentCorrectionEntity(modelCorrection model.ContentCorrection) entity.ContentCorrectionEntity {
+	resp := entity.ContentCorrectionEntity{
+		ID:           modelCorrection.ID,
+		ContentID:    modelCorrection.ContentID,
+		CorrectedAt:  modelCorrection.CorrectedAt,
+		WhatWasWrong: modelCorrection.WhatWasWrong,
+		WhatChanged:  modelCorrection.WhatChanged,
+		Severity:     modelCorrection.Severity,
+		CreatedAt:    modelCorrection.CreatedAt,
+	}
+	if modelCorrection.Content != nil {
+		resp.ContentTitle = modelCorrection.Content.Title
+	}
+	if modelCorrection.CreatedByID != nil {
+		resp.CreatedByID = *modelCorrection.CreatedByID
+	}
+	if modelCorrection.CreatedBy != nil {
+		resp.CreatedBy = &entity.UserEntity{
+			ID:   modelCorrection.CreatedBy.ID,
+			Name: modelCorrection.CreatedBy.Name,
+		}
+	}
+
+	return resp
+}
+
+// toContentCorrectionEntities orders preloaded corrections newest first.
+func toContentCorrectionEntities(modelCorrections []model.ContentCorrection) []entity.ContentCorrectionEntity {
+	slices.SortFunc(modelCorrections, func(a, b model.ContentCorrection) int {
+		if !a.CorrectedAt.Equal(b.CorrectedAt) {
+			return b.CorrectedAt.Compare(a.CorrectedAt)
+		}
+
+		return int(b.ID - a.ID)
+	})
+
+	resps := []entity.ContentCorrectionEntity{}
+	for _, val := range modelCorrections {
+		resps = append(resps, toContentCorrectionEntity(val))
+	}
+
+	return resps
+}
+
+func NewContentCorrectionRepository(db *gorm.DB) ContentCorrectionRepository {
+	return &contentCorrectionRepository{db: db}
+}
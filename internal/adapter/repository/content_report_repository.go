@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var ErrReportStatusChanged = errors.New("Report Was Moderated By Someone Else, Reload And Try Again")
+
+type ContentReportRepository interface {
+	CreateReport(ctx context.Context, req entity.ContentReportEntity) (int64, error)
+	GetReports(ctx context.Context, query entity.ContentReportQuery) ([]entity.ContentReportEntity, int64, int64, error)
+	GetReportByID(ctx context.Context, id int64) (*entity.ContentReportEntity, error)
+	ModerateReport(ctx context.Context, req entity.ContentReportEntity, fromStatus string, flagContent bool) error
+}
+
+type contentReportRepository struct {
+	db *gorm.DB
+}
+
+// CreateReport implements ContentReportRepository.
+func (c *contentReportRepository) CreateReport(ctx context.Context, req entity.ContentReportEntity) (int64, error) {
+	modelReport := model.ContentReport{
+		ContentID: req.ContentID,
+		Reason:    req.Reason,
+		Details:   req.Details,
+		Email:     req.Email,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Status:    entity.ReportStatusNew,
+		CreatedAt: time.Now(),
+	}
+
+	err = c.db.Omit("Content", "Moderator").Create(&modelReport).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateReport - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return modelReport.ID, nil
+}
+
+// GetReports implements ContentReportRepository. Open reports come first,
+// oldest first, so the queue is worked through in order.
+func (c *contentReportRepository) GetReports(ctx context.Context, query entity.ContentReportQuery) ([]entity.ContentReportEntity, int64, int64, error) {
+	var modelReports []model.ContentReport
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := c.db.Model(&model.ContentReport{}).Preload("Content").Preload("Moderator")
+	if query.Status != "" {
+		sqlMain = sqlMain.Where("status = ?", query.Status)
+	}
+
+	if query.ContentID > 0 {
+		sqlMain = sqlMain.Where("content_id = ?", query.ContentID)
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetReports - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("CASE WHEN status IN ('NEW', 'TRIAGED') THEN 0 ELSE 1 END, created_at ASC, id ASC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelReports).Error
+	if err != nil {
+		code = "[REPOSITORY] GetReports - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.ContentReportEntity{}
+	for _, val := range modelReports {
+		resps = append(resps, toContentReportEntity(val))
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// GetReportByID implements ContentReportRepository.
+func (c *contentReportRepository) GetReportByID(ctx context.Context, id int64) (*entity.ContentReportEntity, error) {
+	var modelReport model.ContentReport
+	err = c.db.Preload("Content").Preload("Moderator").Where("id = ?", id).First(&modelReport).Error
+	if err != nil {
+		code = "[REPOSITORY] GetReportByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toContentReportEntity(modelReport)
+	return &resp, nil
+}
+
+// ModerateReport implements ContentReportRepository. The report only moves
+// when it still has fromStatus. With flagContent the reported content is marked
+// as needing review in the same transaction, which also writes a revision.
+func (c *contentReportRepository) ModerateReport(ctx context.Context, req entity.ContentReportEntity, fromStatus string, flagContent bool) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":         req.Status,
+			"moderator_note": req.ModeratorNote,
+			"moderator_id":   req.ModeratorID,
+			"resolved_at":    req.ResolvedAt,
+			"updated_at":     now,
+		}
+
+		result := tx.Model(&model.ContentReport{}).
+			Where("id = ? AND status = ?", req.ID, fromStatus).
+			Updates(updates)
+		if result.Error != nil {
+			code = "[REPOSITORY] ModerateReport - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrReportStatusChanged
+		}
+
+		if !flagContent {
+			return nil
+		}
+
+		err := tx.Model(&model.Content{}).Where("id = ?", req.ContentID).Updates(map[string]interface{}{
+			"is_valid":   entity.ContentValidityNeedsReview,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] ModerateReport - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		_, err = createContentRevision(tx, req.ContentID, req.ModeratorID, nil)
+		return err
+	})
+}
+
+func toContentReportEntity(modelReport model.ContentReport) entity.ContentReportEntity {
+	resp := entity.ContentReportEntity{
+		ID:            modelReport.ID,
+		ContentID:     modelReport.ContentID,
+		Reason:        modelReport.Reason,
+		Details:       modelReport.Details,
+		Email:         modelReport.Email,
+		IPAddress:     modelReport.IPAddress,
+		UserAgent:     modelReport.UserAgent,
+		Status:        modelReport.Status,
+		ModeratorNote: modelReport.ModeratorNote,
+		ResolvedAt:    modelReport.ResolvedAt,
+		CreatedAt:     modelReport.CreatedAt,
+		UpdatedAt:     modelReport.UpdatedAt,
+	}
+	if modelReport.Content != nil {
+		resp.ContentTitle = modelReport.Content.Title
+	}
+	if modelReport.ModeratorID != nil {
+		resp.ModeratorID = *modelReport.ModeratorID
+	}
+	if modelReport.Moderator != nil {
+		resp.Moderator = &entity.UserEntity{
+			ID:   modelReport.Moderator.ID,
+			Name: modelReport.Moderator.Name,
+		}
+	}
+
+	return resp
+}
+
+func NewContentReportRepository(db *gorm.DB) ContentReportRepository {
+	return &contentReportRepository{db: db}
+}
@@ -2,23 +2,106 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
+	"html"
+	"maps"
 	"math"
+	"slices"
 	"strings"
+	"time"
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/domain/model"
+	"trustnews/lib/conv"
+	"trustnews/lib/sorting"
 
 	"github.com/gofiber/fiber/v2/log"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+var ErrContentStatusChanged = errors.New("Content Status Was Changed By Someone Else, Reload And Try Again")
+
 type ContentRepository interface {
 	GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, int64, error)
+	GetContentsByCursor(ctx context.Context, query entity.QueryString) (*entity.ContentPageEntity, error)
+	GetContentIDs(ctx context.Context, query entity.QueryString) ([]int64, error)
 	GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error)
-	CreateContent(ctx context.Context, req entity.ContentEntity) error
-	UpdateContent(ctx context.Context, req entity.ContentEntity) error
+	GetContentBySlug(ctx context.Context, slug string) (*entity.ContentEntity, error)
+	CreateContent(ctx context.Context, req entity.ContentEntity) (int64, error)
+	UpdateContent(ctx context.Context, req entity.ContentEntity, reopen *entity.ContentReviewEntity) error
 	DeleteContent(ctx context.Context, id int64) error
+	IncrementViewCount(ctx context.Context, id int64) error
+
+	GetRevisions(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.ContentRevisionEntity, int64, int64, error)
+	GetRevision(ctx context.Context, contentID int64, revisionNumber int) (*entity.ContentRevisionEntity, error)
+	RestoreRevision(ctx context.Context, contentID int64, revisionNumber int, editorID int64, tags []entity.TagEntity, reopen *entity.ContentReviewEntity) (int, error)
+
+	TransitionContent(ctx context.Context, review entity.ContentReviewEntity) error
+	GetContentReviews(ctx context.Context, contentID int64) ([]entity.ContentReviewEntity, error)
+	ApplyContentSchedule(ctx context.Context, now time.Time) ([]entity.ContentReviewEntity, error)
+}
+
+// contentSearchQuery turns a web search style query into a tsquery matching
+// either text search configuration of search_vector.
+const contentSearchQuery = "(websearch_to_tsquery('indonesian', ?) || websearch_to_tsquery('english', ?))"
+
+// ts_headline options. Snippets are taken from the description without markup.
+// Matches are wrapped in private use characters rather than <mark>, the text
+// around them is escaped before they are turned into <mark> by safeHeadline.
+const (
+	headlineStartSel       = "\uE000"
+	headlineStopSel        = "\uE001"
+	titleHeadlineOptions   = `StartSel="` + headlineStartSel + `", StopSel="` + headlineStopSel + `", HighlightAll=true`
+	snippetHeadlineOptions = `StartSel="` + headlineStartSel + `", StopSel="` + headlineStopSel + `", MaxFragments=2, MinWords=15, MaxWords=35, FragmentDelimiter=" ... "`
+)
+
+// headlineReplacer escapes a headline and marks its matches.
+var headlineReplacer = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&#34;", "'", "&#39;",
+	headlineStartSel, "<mark>", headlineStopSel, "</mark>",
+)
+
+// safeHeadline returns a ts_headline result as HTML like the bleve highlighter
+// does. The snippet is taken from the description which may hold entities,
+// they are decoded first so they are not escaped twice.
+func safeHeadline(headline string, decode bool) string {
+	if decode {
+		headline = html.UnescapeString(headline)
+	}
+
+	return headlineReplacer.Replace(headline)
+}
+
+// contentSortColumns maps the content sort keys to what they sort on. Without a
+// search relevance has nothing to rank, it sorts on the creation date instead.
+var contentSortColumns = map[string]clause.Expr{
+	entity.ContentSortID:          {SQL: "id"},
+	entity.ContentSortTitle:       {SQL: "title"},
+	entity.ContentSortStatus:      {SQL: "status"},
+	entity.ContentSortCreatedAt:   {SQL: "created_at"},
+	entity.ContentSortUpdatedAt:   {SQL: "COALESCE(updated_at, created_at)"},
+	entity.ContentSortPublishedAt: {SQL: "COALESCE(publish_at, created_at)"},
+	entity.ContentSortPopularity:  {SQL: "view_count"},
+	entity.ContentSortRelevance:   {SQL: "created_at"},
+}
+
+// contentScheduleLockKey is the advisory lock taken by ApplyContentSchedule, so
+// only one instance works through the schedule at a time.
+const contentScheduleLockKey = 7341001
+
+// contentScheduleBatch caps how many contents one run moves per step.
+const contentScheduleBatch = 100
+
+// contentScheduleSteps are the status changes made when a time is reached.
+var contentScheduleSteps = []struct {
+	Action string
+	From   string
+	To     string
+	Column string
+}{
+	{entity.ContentActionPublish, entity.ContentStatusScheduled, entity.ContentStatusPublished, "publish_at"},
+	{entity.ContentActionArchive, entity.ContentStatusPublished, entity.ContentStatusArchived, "unpublish_at"},
 }
 
 type contentRepository struct {
@@ -26,35 +109,92 @@ type contentRepository struct {
 }
 
 // CreateContent implements ContentRepository.
-func (c *contentRepository) CreateContent(ctx context.Context, req entity.ContentEntity) error {
-	tags := strings.Join(req.Tags, ",")
+func (c *contentRepository) CreateContent(ctx context.Context, req entity.ContentEntity) (int64, error) {
 	modelContent := model.Content{
 		Title: req.Title,
 		Excerpt: req.Excerpt,
 		Description: req.Description,
 		Image: req.Image,
-		Tags: tags,
 		Status: req.Status,
 		IsValid: req.IsValid,
 		CategoryID: req.CategoryID,
 		CreatedByID: req.CreatedByID,
+		PublishAt: req.PublishAt,
+		UnpublishAt: req.UnpublishAt,
 	}
 
-	err := c.db.Create(&modelContent).Error
-	if err != nil {
-		code = "[REPOSITORY] CreateContent - 1"
-		log.Errorw(code, err)
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		slug, err := uniqueSlug(tx, contentSlugs, req.Slug, 0)
+		if err != nil {
+			code = "[REPOSITORY] CreateContent - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		modelContent.Slug = slug
+		err = tx.Create(&modelContent).Error
+		if err != nil {
+			code = "[REPOSITORY] CreateContent - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = replaceContentTags(tx, modelContent.ID, req.Tags)
+		if err != nil {
+			return err
+		}
+
+		err = replaceContentSources(tx, modelContent.ID, req.Sources)
+		if err != nil {
+			return err
+		}
+
+		_, err = createContentRevision(tx, modelContent.ID, req.CreatedByID, nil)
 		return err
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return modelContent.ID, nil
 }
 
 // DeleteContent implements ContentRepository.
 func (c *contentRepository) DeleteContent(ctx context.Context, id int64) error {
-	err = c.db.Where("id = ?", id).Delete(&model.Content{}).Error
+	// the tag links go with the content, the tags it had are counted again
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var tagIDs []int64
+		err := tx.Model(&model.ContentTag{}).Where("content_id = ?", id).Pluck("tag_id", &tagIDs).Error
+		if err != nil {
+			code = "[REPOSITORY] DeleteContent - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("id = ?", id).Delete(&model.Content{}).Error
+		if err != nil {
+			code = "[REPOSITORY] DeleteContent - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recountTagUsage(tx, tagIDs)
+		if err != nil {
+			code = "[REPOSITORY] DeleteContent - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// IncrementViewCount implements ContentRepository. The column is bumped in
+// place so a visit never touches updated_at or races an edit.
+func (c *contentRepository) IncrementViewCount(ctx context.Context, id int64) error {
+	err = c.db.Model(&model.Content{}).Where("id = ?", id).UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
 	if err != nil {
-		code = "[REPOSITORY] DeleteContent - 1"
+		code = "[REPOSITORY] IncrementViewCount - 1"
 		log.Errorw(code, err)
 		return err
 	}
@@ -66,25 +206,36 @@ func (c *contentRepository) DeleteContent(ctx context.Context, id int64) error {
 func (c *contentRepository) GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error) {
 	var modelContent model.Content
 
-	err = c.db.Where("id = ?", id).Preload(clause.Associations).First(&modelContent).Error
+	err = c.db.Where("id = ?", id).Preload(clause.Associations).Preload("FactCheck.Reviewer").First(&modelContent).Error
 	if err != nil {
 		code = "[REPOSITORY] GetContentByID - 1"
 		log.Errorw(code, err)
 		return nil, err
 	}
-	tags := strings.Split(modelContent.Tags, ",")
+
+	tags, err := loadContentTags(c.db, []int64{modelContent.ID})
+	if err != nil {
+		code = "[REPOSITORY] GetContentByID - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
 	resp := entity.ContentEntity{
 		ID: modelContent.ID,
 		Title: modelContent.Title,
+		Slug: modelContent.Slug,
 		Excerpt: modelContent.Excerpt,
 		Description: modelContent.Description,
 		Image: modelContent.Image,
-		Tags: tags,
+		Tags: tags[modelContent.ID],
 		Status: modelContent.Status,
 		IsValid: modelContent.IsValid,
 		CategoryID: modelContent.CategoryID,
 		CreatedByID: modelContent.CreatedByID,
+		PublishAt: modelContent.PublishAt,
+		UnpublishAt: modelContent.UnpublishAt,
 		CreatedAt: modelContent.CreatedAt,
+		UpdatedAt: modelContent.UpdatedAt,
 		Category: entity.CategoryEntity{
 			ID: modelContent.Category.ID,
 			Title: modelContent.Category.Title,
@@ -94,31 +245,35 @@ func (c *contentRepository) GetContentByID(ctx context.Context, id int64) (*enti
 			ID: modelContent.User.ID,
 			Name: modelContent.User.Name,
 		},
+		FactCheck: toFactCheckEntity(modelContent.FactCheck),
+		Sources: toContentSourceEntities(modelContent.Sources),
+		Corrections: toContentCorrectionEntities(modelContent.Corrections),
 	}
 
 	return &resp, nil
 }
 
+// GetContentBySlug implements ContentRepository. Old slugs resolve to the
+// content as well, callers compare the returned Slug to spot them.
+func (c *contentRepository) GetContentBySlug(ctx context.Context, slug string) (*entity.ContentEntity, error) {
+	id, err := slugOwner(c.db, contentSlugs, slug)
+	if err != nil {
+		code = "[REPOSITORY] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return c.GetContentByID(ctx, id)
+}
+
 // GetContents implements ContentRepository.
 func (c *contentRepository) GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, int64, error) {
 	var modelContents []model.Content
 	var countData int64
 
-	order := fmt.Sprintf("%s %s", query.OrderBy, query.OrderType)
 	offset := (query.Page - 1) * query.Limit
-	status := ""
-	if query.Status != "" {
-		status = query.Status
-	}
-
-	sqlMain := c.db.Preload(clause.Associations).
-		Where("title ilike ? OR excerpt ilike ? OR description ilike ?", "%"+query.Search+"%", "%"+query.Search+"%", "%"+query.Search+"%").
-		Where("status LIKE ?", "%"+status+"%")
-
-	if query.CategoryID > 0 {
-		sqlMain = sqlMain.Where("category_id =?", query.CategoryID)
-	}
 
+	sqlMain := c.filterContents(query)
 	err = sqlMain.Model(&modelContents).Count(&countData).Error
 	if err != nil {
 		code = "[REPOSITORY] GetContents - 1"
@@ -128,8 +283,9 @@ func (c *contentRepository) GetContents(ctx context.Context, query entity.QueryS
 
 	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
 
+	sqlMain, columns := selectContentSearch(sqlMain, query)
 	err = sqlMain.
-		Order(order).
+		Order(sorting.OrderBy(sorting.WithTiebreak(query.Sort, entity.ContentSortID), columns)).
 		Limit(query.Limit).
 		Offset(offset).
 		Find(&modelContents).Error
@@ -139,21 +295,253 @@ func (c *contentRepository) GetContents(ctx context.Context, query entity.QueryS
 		return nil, 0, 0, err
 	}
 
+	resps, err := c.toContentEntities(modelContents, query.Search != "")
+	if err != nil {
+		code = "[REPOSITORY] GetContents - 3"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// GetContentsByCursor implements ContentRepository. Pages are found by the sort
+// values of the row at the cursor instead of an offset, so deep pages stay
+// fast and contents published in between do not shift them.
+func (c *contentRepository) GetContentsByCursor(ctx context.Context, query entity.QueryString) (*entity.ContentPageEntity, error) {
+	var modelContents []model.Content
+	var countData int64
+
+	sqlMain := c.filterContents(query)
+	err = sqlMain.Model(&modelContents).Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetContentsByCursor - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	sqlMain, columns := selectContentSearch(sqlMain, query)
+	keys := sorting.WithTiebreak(query.Sort, entity.ContentSortID)
+
+	// the page before a cursor is read backwards from it and turned around
+	backward := query.Cursor != nil && query.Cursor.Backward
+	order := keys
+	if backward {
+		order = sorting.Reverse(keys)
+	}
+	if query.Cursor != nil {
+		sqlMain = sqlMain.Where(sorting.Seek(order, columns, query.Cursor.Values))
+	}
+
+	// one row more than asked for tells whether the list goes on
+	err = sqlMain.
+		Order(sorting.OrderBy(order, columns)).
+		Limit(query.Limit + 1).
+		Find(&modelContents).Error
+	if err != nil {
+		code = "[REPOSITORY] GetContentsByCursor - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	more := len(modelContents) > query.Limit
+	if more {
+		modelContents = modelContents[:query.Limit]
+	}
+	if backward {
+		slices.Reverse(modelContents)
+	}
+
+	contents, err := c.toContentEntities(modelContents, query.Search != "")
+	if err != nil {
+		code = "[REPOSITORY] GetContentsByCursor - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	page := &entity.ContentPageEntity{
+		Contents:   contents,
+		TotalData:  countData,
+		TotalPages: int64(math.Ceil(float64(countData) / float64(query.Limit))),
+	}
+	if len(modelContents) == 0 {
+		return page, nil
+	}
+
+	// the side a cursor came from always has a page
+	hasNext, hasPrev := more, query.Cursor != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		page.Next = &entity.CursorEntity{Values: contentSortValues(modelContents[len(modelContents)-1], keys, query.Search != "")}
+	}
+	if hasPrev {
+		page.Prev = &entity.CursorEntity{Values: contentSortValues(modelContents[0], keys, query.Search != ""), Backward: true}
+	}
+
+	return page, nil
+}
+
+// GetContentIDs implements ContentRepository. Paging and sorting of query are
+// ignored.
+func (c *contentRepository) GetContentIDs(ctx context.Context, query entity.QueryString) ([]int64, error) {
+	ids := []int64{}
+	err = c.filterContents(query).Model(&model.Content{}).Pluck("id", &ids).Error
+	if err != nil {
+		code = "[REPOSITORY] GetContentIDs - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// filterContents applies the filters of query, the result can be counted and
+// then paged.
+func (c *contentRepository) filterContents(query entity.QueryString) *gorm.DB {
+	sqlMain := c.db.Preload(clause.Associations)
+	if query.IDs != nil {
+		sqlMain = sqlMain.Where("id IN ?", query.IDs)
+	}
+
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("search_vector @@ "+contentSearchQuery, query.Search, query.Search)
+	}
+
+	if query.Status != "" {
+		sqlMain = sqlMain.Where("status = ?", query.Status)
+	}
+
+	// a parent category lists the contents of its subcategories as well
+	if query.CategoryID > 0 {
+		sqlMain = sqlMain.Where("category_id IN (?)", categorySubtree(c.db, query.CategoryID))
+	}
+
+	// a domain also matches its subdomains, news.example.com cites example.com.
+	// The domain comes from the query, its wildcards are escaped
+	if query.SourceDomain != "" {
+		cited := c.db.Model(&model.ContentSource{}).
+			Select("content_id").
+			Where(`domain = ? OR domain LIKE ? ESCAPE '\'`, query.SourceDomain, "%."+likeEscaper.Replace(query.SourceDomain))
+		sqlMain = sqlMain.Where("id IN (?)", cited)
+	}
+
+	if query.TagID > 0 {
+		tagged := c.db.Model(&model.ContentTag{}).Select("content_id").Where("tag_id = ?", query.TagID)
+		sqlMain = sqlMain.Where("id IN (?)", tagged)
+	}
+
+	if query.AuthorID > 0 {
+		sqlMain = sqlMain.Where("created_by_id = ?", query.AuthorID)
+	}
+
+	// contents without a publish date count from their creation
+	if query.From != nil {
+		sqlMain = sqlMain.Where("COALESCE(publish_at, created_at) >= ?", *query.From)
+	}
+
+	if query.To != nil {
+		sqlMain = sqlMain.Where("COALESCE(publish_at, created_at) <= ?", *query.To)
+	}
+
+	return sqlMain
+}
+
+// selectContentSearch highlights the matches of a search and makes relevance
+// sort on their rank, it returns the columns to sort on.
+func selectContentSearch(sqlMain *gorm.DB, query entity.QueryString) (*gorm.DB, map[string]clause.Expr) {
+	if query.Search == "" {
+		return sqlMain, contentSortColumns
+	}
+
+	rank := clause.Expr{
+		SQL:  "ts_rank_cd(search_vector, " + contentSearchQuery + ")",
+		Vars: []interface{}{query.Search, query.Search},
+	}
+	sqlMain = sqlMain.Select("contents.*, "+
+		"ts_headline('indonesian', title, "+contentSearchQuery+", ?) AS search_title, "+
+		"ts_headline('indonesian', regexp_replace(description, '<[^>]*>', ' ', 'g'), "+contentSearchQuery+", ?) AS search_snippet, "+
+		rank.SQL+" AS search_rank",
+		query.Search, query.Search, titleHeadlineOptions, query.Search, query.Search, snippetHeadlineOptions, query.Search, query.Search)
+
+	columns := maps.Clone(contentSortColumns)
+	columns[entity.ContentSortRelevance] = rank
+
+	return sqlMain, columns
+}
+
+// contentSortValues returns what content has for each of keys, the values a
+// cursor at content seeks from. They have to match contentSortColumns.
+func contentSortValues(content model.Content, keys []sorting.Key, search bool) []interface{} {
+	values := []interface{}{}
+	for _, key := range keys {
+		var value interface{}
+		switch key.Name {
+		case entity.ContentSortTitle:
+			value = content.Title
+		case entity.ContentSortStatus:
+			value = content.Status
+		case entity.ContentSortCreatedAt:
+			value = content.CreatedAt
+		case entity.ContentSortUpdatedAt:
+			value = content.CreatedAt
+			if content.UpdatedAt != nil {
+				value = *content.UpdatedAt
+			}
+		case entity.ContentSortPublishedAt:
+			value = content.CreatedAt
+			if content.PublishAt != nil {
+				value = *content.PublishAt
+			}
+		case entity.ContentSortPopularity:
+			value = content.ViewCount
+		case entity.ContentSortRelevance:
+			value = content.CreatedAt
+			if search {
+				value = content.SearchRank
+			}
+		default:
+			value = content.ID
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// toContentEntities maps listed contents to entities with their tags, search
+// results get their highlights.
+func (c *contentRepository) toContentEntities(modelContents []model.Content, search bool) ([]entity.ContentEntity, error) {
+	contentIDs := []int64{}
+	for _, val := range modelContents {
+		contentIDs = append(contentIDs, val.ID)
+	}
+
+	tags, err := loadContentTags(c.db, contentIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	resps := []entity.ContentEntity{}
 	for _, val := range modelContents {
-		tags := strings.Split(val.Tags, ",")
 		resp := entity.ContentEntity{
 			ID: val.ID,
 			Title: val.Title,
+			Slug: val.Slug,
 			Excerpt: val.Excerpt,
 			Description: val.Description,
 			Image: val.Image,
-			Tags: tags,
+			Tags: tags[val.ID],
 			Status: val.Status,
 			IsValid: val.IsValid,
 			CategoryID: val.CategoryID,
 			CreatedByID: val.CreatedByID,
+			PublishAt: val.PublishAt,
+			UnpublishAt: val.UnpublishAt,
 			CreatedAt: val.CreatedAt,
+			UpdatedAt: val.UpdatedAt,
 			Category: entity.CategoryEntity{
 				ID: val.Category.ID,
 				Title: val.Category.Title,
@@ -163,32 +551,444 @@ func (c *contentRepository) GetContents(ctx context.Context, query entity.QueryS
 				ID: val.User.ID,
 				Name: val.User.Name,
 			},
+			FactCheck: toFactCheckEntity(val.FactCheck),
+			Sources: toContentSourceEntities(val.Sources),
+		}
+		if search {
+			resp.Highlight = &entity.ContentHighlightEntity{
+				Title: safeHeadline(val.SearchTitle, false),
+				Snippet: safeHeadline(val.SearchSnippet, true),
+			}
 		}
 
 		resps = append(resps, resp)
 	}
 
-	return resps, countData, int64(totalPages), nil
+	return resps, nil
 }
 
-// UpdateContent implements ContentRepository.
-func (c *contentRepository) UpdateContent(ctx context.Context, req entity.ContentEntity) error {
-	tags := strings.Join(req.Tags, ",")
+// UpdateContent implements ContentRepository. A non nil reopen moves the status
+// back in the same transaction, so the edit is never stored without it.
+func (c *contentRepository) UpdateContent(ctx context.Context, req entity.ContentEntity, reopen *entity.ContentReviewEntity) error {
 	modelContent := model.Content{
 		Title: req.Title,
 		Excerpt: req.Excerpt,
 		Description: req.Description,
 		Image: req.Image,
-		Tags: tags,
 		Status: req.Status,
 		IsValid: req.IsValid,
 		CategoryID: req.CategoryID,
 		CreatedByID: req.CreatedByID,
 	}
 
-	err = c.db.Where("id = ?", req.ID).Updates(&modelContent).Error
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if reopen != nil {
+			err := applyTransition(tx, *reopen)
+			if err != nil {
+				return err
+			}
+		}
+
+		// a new slug moves the current one into the history, so old links
+		// can be redirected
+		if req.Slug != "" {
+			var current model.Content
+			err := tx.Select("slug").Where("id = ?", req.ID).First(&current).Error
+			if err != nil {
+				code = "[REPOSITORY] UpdateContent - 1"
+				log.Errorw(code, err)
+				return err
+			}
+
+			if req.Slug != current.Slug {
+				modelContent.Slug, err = uniqueSlug(tx, contentSlugs, req.Slug, req.ID)
+				if err != nil {
+					code = "[REPOSITORY] UpdateContent - 2"
+					log.Errorw(code, err)
+					return err
+				}
+
+				err = recordSlugChange(tx, contentSlugs, req.ID, current.Slug, modelContent.Slug)
+				if err != nil {
+					code = "[REPOSITORY] UpdateContent - 3"
+					log.Errorw(code, err)
+					return err
+				}
+			}
+		}
+
+		err := tx.Where("id = ?", req.ID).Updates(&modelContent).Error
+		if err != nil {
+			code = "[REPOSITORY] UpdateContent - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		// Updates skips nil pointers, the schedule has to be written on its own
+		// so it can be cleared
+		err = tx.Model(&model.Content{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+			"publish_at":   req.PublishAt,
+			"unpublish_at": req.UnpublishAt,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] UpdateContent - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if req.Tags != nil {
+			err = replaceContentTags(tx, req.ID, req.Tags)
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.Sources != nil {
+			err = replaceContentSources(tx, req.ID, req.Sources)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = createContentRevision(tx, req.ID, req.EditedByID, nil)
+		return err
+	})
+}
+
+// GetRevisions implements ContentRepository. Newest revisions come first.
+func (c *contentRepository) GetRevisions(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.ContentRevisionEntity, int64, int64, error) {
+	var modelRevisions []model.ContentRevision
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := c.db.Model(&model.ContentRevision{}).Preload("EditedBy").Where("content_id = ?", contentID)
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRevisions - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("revision_number DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelRevisions).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRevisions - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.ContentRevisionEntity{}
+	for _, val := range modelRevisions {
+		resps = append(resps, toContentRevisionEntity(val))
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// GetRevision implements ContentRepository.
+func (c *contentRepository) GetRevision(ctx context.Context, contentID int64, revisionNumber int) (*entity.ContentRevisionEntity, error) {
+	var modelRevision model.ContentRevision
+	err = c.db.Preload("EditedBy").
+		Where("content_id = ? AND revision_number = ?", contentID, revisionNumber).
+		First(&modelRevision).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRevision - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toContentRevisionEntity(modelRevision)
+	return &resp, nil
+}
+
+// RestoreRevision implements ContentRepository. The content gets every field of
+// the old revision back, including empty ones, and the result is stored as a
+// new revision so the history itself is never rewritten. Revisions made before
+// sources were recorded leave the sources alone. The status only moves when
+// reopen moves it back in the same transaction, otherwise it goes through
+// TransitionContent, and is_valid is left alone since it follows the fact check.
+func (c *contentRepository) RestoreRevision(ctx context.Context, contentID int64, revisionNumber int, editorID int64, tags []entity.TagEntity, reopen *entity.ContentReviewEntity) (int, error) {
+	var newRevision int
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		var modelRevision model.ContentRevision
+		err := tx.Where("content_id = ? AND revision_number = ?", contentID, revisionNumber).First(&modelRevision).Error
+		if err != nil {
+			code = "[REPOSITORY] RestoreRevision - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		result := tx.Model(&model.Content{}).Where("id = ?", contentID).Updates(map[string]interface{}{
+			"title":       modelRevision.Title,
+			"excerpt":     modelRevision.Excerpt,
+			"description": modelRevision.Description,
+			"image":       modelRevision.Image,
+			"category_id": modelRevision.CategoryID,
+			"updated_at":  time.Now(),
+		})
+		if result.Error != nil {
+			code = "[REPOSITORY] RestoreRevision - 2"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if reopen != nil {
+			err = applyTransition(tx, *reopen)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = replaceContentTags(tx, contentID, tags)
+		if err != nil {
+			return err
+		}
+
+		if sources := fromRevisionSources(modelRevision.Sources); sources != nil {
+			err = replaceContentSources(tx, contentID, sources)
+			if err != nil {
+				return err
+			}
+		}
+
+		newRevision, err = createContentRevision(tx, contentID, editorID, &revisionNumber)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return newRevision, nil
+}
+
+// TransitionContent implements ContentRepository. The status only changes when
+// it still is review.FromStatus, so two reviewers cannot act on the same state.
+func (c *contentRepository) TransitionContent(ctx context.Context, review entity.ContentReviewEntity) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		err := applyTransition(tx, review)
+		if err != nil {
+			return err
+		}
+
+		_, err = createContentRevision(tx, review.ContentID, review.ReviewerID, nil)
+		return err
+	})
+}
+
+// applyTransition changes the status and records the review inside tx. The
+// caller stores the revision.
+func applyTransition(tx *gorm.DB, review entity.ContentReviewEntity) error {
+	result := tx.Model(&model.Content{}).
+		Where("id = ? AND status = ?", review.ContentID, review.FromStatus).
+		Updates(map[string]interface{}{
+			"status":     review.ToStatus,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		code = "[REPOSITORY] applyTransition - 1"
+		log.Errorw(code, result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrContentStatusChanged
+	}
+
+	modelReview := model.ContentReview{
+		ContentID:  review.ContentID,
+		Action:     review.Action,
+		FromStatus: review.FromStatus,
+		ToStatus:   review.ToStatus,
+		Comment:    review.Comment,
+		CreatedAt:  time.Now(),
+	}
+	if review.ReviewerID > 0 {
+		modelReview.ReviewerID = &review.ReviewerID
+	}
+
+	err := tx.Omit("Reviewer").Create(&modelReview).Error
+	if err != nil {
+		code = "[REPOSITORY] applyTransition - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetContentReviews implements ContentRepository. Oldest entries come first.
+func (c *contentRepository) GetContentReviews(ctx context.Context, contentID int64) ([]entity.ContentReviewEntity, error) {
+	var modelReviews []model.ContentReview
+	err = c.db.Preload("Reviewer").
+		Where("content_id = ?", contentID).
+		Order("created_at ASC, id ASC").
+		Find(&modelReviews).Error
+	if err != nil {
+		code = "[REPOSITORY] GetContentReviews - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resps := []entity.ContentReviewEntity{}
+	for _, val := range modelReviews {
+		resp := entity.ContentReviewEntity{
+			ID:         val.ID,
+			ContentID:  val.ContentID,
+			Action:     val.Action,
+			FromStatus: val.FromStatus,
+			ToStatus:   val.ToStatus,
+			Comment:    val.Comment,
+			CreatedAt:  val.CreatedAt,
+		}
+		if val.Reviewer != nil {
+			resp.ReviewerID = val.Reviewer.ID
+			resp.Reviewer = &entity.UserEntity{
+				ID:   val.Reviewer.ID,
+				Name: val.Reviewer.Name,
+				Role: val.Reviewer.Role,
+			}
+		}
+
+		resps = append(resps, resp)
+	}
+
+	return resps, nil
+}
+
+// ApplyContentSchedule implements ContentRepository. It publishes scheduled
+// contents whose publish_at has passed and archives published contents whose
+// unpublish_at has passed. When another instance holds the advisory lock the
+// run is skipped, and rows locked by an editor right now are left for the next
+// run. It returns the status changes it made.
+func (c *contentRepository) ApplyContentSchedule(ctx context.Context, now time.Time) ([]entity.ContentReviewEntity, error) {
+	applied := []entity.ContentReviewEntity{}
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", contentScheduleLockKey).Scan(&locked).Error
+		if err != nil {
+			code = "[REPOSITORY] ApplyContentSchedule - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		for _, step := range contentScheduleSteps {
+			var ids []int64
+			err = tx.Model(&model.Content{}).
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("status = ? AND "+step.Column+" IS NOT NULL AND "+step.Column+" <= ?", step.From, now).
+				Order(step.Column).
+				Limit(contentScheduleBatch).
+				Pluck("id", &ids).Error
+			if err != nil {
+				code = "[REPOSITORY] ApplyContentSchedule - 2"
+				log.Errorw(code, err)
+				return err
+			}
+
+			if len(ids) == 0 {
+				continue
+			}
+
+			err = tx.Model(&model.Content{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+				"status":     step.To,
+				"updated_at": now,
+			}).Error
+			if err != nil {
+				code = "[REPOSITORY] ApplyContentSchedule - 3"
+				log.Errorw(code, err)
+				return err
+			}
+
+			for _, id := range ids {
+				modelReview := model.ContentReview{
+					ContentID:  id,
+					Action:     step.Action,
+					FromStatus: step.From,
+					ToStatus:   step.To,
+					Comment:    "Applied by the scheduler",
+					CreatedAt:  now,
+				}
+
+				err = tx.Omit("Reviewer").Create(&modelReview).Error
+				if err != nil {
+					code = "[REPOSITORY] ApplyContentSchedule - 4"
+					log.Errorw(code, err)
+					return err
+				}
+
+				_, err = createContentRevision(tx, id, 0, nil)
+				if err != nil {
+					return err
+				}
+
+				applied = append(applied, entity.ContentReviewEntity{
+					ID:         modelReview.ID,
+					ContentID:  id,
+					Action:     step.Action,
+					FromStatus: step.From,
+					ToStatus:   step.To,
+					Comment:    modelReview.Comment,
+					CreatedAt:  now,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// replaceContentSources swaps the sources of a content for the given list,
+// keeping the order they were given in.
+func replaceContentSources(tx *gorm.DB, contentID int64, sources []entity.ContentSourceEntity) error {
+	err := tx.Where("content_id = ?", contentID).Delete(&model.ContentSource{}).Error
+	if err != nil {
+		code = "[REPOSITORY] replaceContentSources - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if len(sources) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	modelSources := []model.ContentSource{}
+	for i, source := range sources {
+		modelSources = append(modelSources, model.ContentSource{
+			ContentID:  contentID,
+			Url:        source.Url,
+			Domain:     conv.UrlDomain(source.Url),
+			Publisher:  source.Publisher,
+			AccessedAt: source.AccessedAt,
+			ArchiveUrl: source.ArchiveUrl,
+			Note:       source.Note,
+			SortOrder:  i,
+			CreatedAt:  now,
+		})
+	}
+
+	err = tx.Create(&modelSources).Error
 	if err != nil {
-		code = "[REPOSITORY] UpdateContent - 1"
+		code = "[REPOSITORY] replaceContentSources - 2"
 		log.Errorw(code, err)
 		return err
 	}
@@ -196,6 +996,183 @@ func (c *contentRepository) UpdateContent(ctx context.Context, req entity.Conten
 	return nil
 }
 
+func toContentSourceEntities(modelSources []model.ContentSource) []entity.ContentSourceEntity {
+	slices.SortFunc(modelSources, func(a, b model.ContentSource) int {
+		if a.SortOrder != b.SortOrder {
+			return a.SortOrder - b.SortOrder
+		}
+
+		return int(a.ID - b.ID)
+	})
+
+	resps := []entity.ContentSourceEntity{}
+	for _, val := range modelSources {
+		resps = append(resps, entity.ContentSourceEntity{
+			ID:         val.ID,
+			Url:        val.Url,
+			Domain:     val.Domain,
+			Publisher:  val.Publisher,
+			AccessedAt: val.AccessedAt,
+			ArchiveUrl: val.ArchiveUrl,
+			Note:       val.Note,
+		})
+	}
+
+	return resps
+}
+
+// createContentRevision snapshots the current row of a content inside tx. The
+// row is locked by the surrounding update, so revision numbers cannot clash.
+func createContentRevision(tx *gorm.DB, contentID int64, editorID int64, restoredFrom *int) (int, error) {
+	var modelContent model.Content
+	err := tx.Where("id = ?", contentID).First(&modelContent).Error
+	if err != nil {
+		code = "[REPOSITORY] createContentRevision - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	tags, err := loadContentTags(tx, []int64{contentID})
+	if err != nil {
+		return 0, err
+	}
+
+	var modelSources []model.ContentSource
+	err = tx.Where("content_id = ?", contentID).Find(&modelSources).Error
+	if err != nil {
+		code = "[REPOSITORY] createContentRevision - 2"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	sources, err := json.Marshal(toRevisionSources(toContentSourceEntities(modelSources)))
+	if err != nil {
+		code = "[REPOSITORY] createContentRevision - 3"
+		log.Errorw(code, err)
+		return 0, err
+	}
+	revisionSources := string(sources)
+
+	var revisionNumber int
+	err = tx.Model(&model.ContentRevision{}).
+		Where("content_id = ?", contentID).
+		Select("COALESCE(MAX(revision_number), 0) + 1").
+		Scan(&revisionNumber).Error
+	if err != nil {
+		code = "[REPOSITORY] createContentRevision - 4"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	modelRevision := model.ContentRevision{
+		ContentID:      contentID,
+		RevisionNumber: revisionNumber,
+		Title:          modelContent.Title,
+		Excerpt:        modelContent.Excerpt,
+		Description:    modelContent.Description,
+		Image:          modelContent.Image,
+		Tags:           strings.Join(entity.TagNames(tags[contentID]), ","),
+		Sources:        &revisionSources,
+		Status:         modelContent.Status,
+		IsValid:        modelContent.IsValid,
+		CategoryID:     modelContent.CategoryID,
+		RestoredFrom:   restoredFrom,
+		CreatedAt:      time.Now(),
+	}
+	if editorID > 0 {
+		modelRevision.EditedByID = &editorID
+	}
+
+	err = tx.Omit("EditedBy").Create(&modelRevision).Error
+	if err != nil {
+		code = "[REPOSITORY] createContentRevision - 5"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return revisionNumber, nil
+}
+
+// revisionSource is a source as it is kept in the sources column of a revision.
+type revisionSource struct {
+	Url        string     `json:"url"`
+	Publisher  string     `json:"publisher"`
+	AccessedAt *time.Time `json:"accessed_at"`
+	ArchiveUrl string     `json:"archive_url"`
+	Note       string     `json:"note"`
+}
+
+func toRevisionSources(sources []entity.ContentSourceEntity) []revisionSource {
+	resps := []revisionSource{}
+	for _, source := range sources {
+		resps = append(resps, revisionSource{
+			Url:        source.Url,
+			Publisher:  source.Publisher,
+			AccessedAt: source.AccessedAt,
+			ArchiveUrl: source.ArchiveUrl,
+			Note:       source.Note,
+		})
+	}
+
+	return resps
+}
+
+// fromRevisionSources returns nil for a revision made before sources were
+// recorded.
+func fromRevisionSources(raw *string) []entity.ContentSourceEntity {
+	if raw == nil {
+		return nil
+	}
+
+	var sources []revisionSource
+	if err := json.Unmarshal([]byte(*raw), &sources); err != nil {
+		code = "[REPOSITORY] fromRevisionSources - 1"
+		log.Errorw(code, err)
+		return nil
+	}
+
+	resps := []entity.ContentSourceEntity{}
+	for _, source := range sources {
+		resps = append(resps, entity.ContentSourceEntity{
+			Url:        source.Url,
+			Domain:     conv.UrlDomain(source.Url),
+			Publisher:  source.Publisher,
+			AccessedAt: source.AccessedAt,
+			ArchiveUrl: source.ArchiveUrl,
+			Note:       source.Note,
+		})
+	}
+
+	return resps
+}
+
+func toContentRevisionEntity(modelRevision model.ContentRevision) entity.ContentRevisionEntity {
+	resp := entity.ContentRevisionEntity{
+		ID:             modelRevision.ID,
+		ContentID:      modelRevision.ContentID,
+		RevisionNumber: modelRevision.RevisionNumber,
+		Title:          modelRevision.Title,
+		Excerpt:        modelRevision.Excerpt,
+		Description:    modelRevision.Description,
+		Image:          modelRevision.Image,
+		Tags:           conv.SplitTags(modelRevision.Tags),
+		Sources:        fromRevisionSources(modelRevision.Sources),
+		Status:         modelRevision.Status,
+		IsValid:        modelRevision.IsValid,
+		CategoryID:     modelRevision.CategoryID,
+		RestoredFrom:   modelRevision.RestoredFrom,
+		CreatedAt:      modelRevision.CreatedAt,
+	}
+	if modelRevision.EditedBy != nil {
+		resp.EditedBy = &entity.UserEntity{
+			ID:   modelRevision.EditedBy.ID,
+			Name: modelRevision.EditedBy.Name,
+		}
+	}
+
+	return resp
+}
+
 func NewContentRepository(db *gorm.DB) ContentRepository {
 	return &contentRepository{db: db}
 }
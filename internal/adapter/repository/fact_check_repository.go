@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FactCheckRepository interface {
+	GetFactCheckByContentID(ctx context.Context, contentID int64) (*entity.FactCheckEntity, error)
+	SaveFactCheck(ctx context.Context, req entity.FactCheckEntity) error
+	DeleteFactCheck(ctx context.Context, contentID int64) error
+}
+
+type factCheckRepository struct {
+	db *gorm.DB
+}
+
+// GetFactCheckByContentID implements FactCheckRepository.
+func (f *factCheckRepository) GetFactCheckByContentID(ctx context.Context, contentID int64) (*entity.FactCheckEntity, error) {
+	var modelFactCheck model.FactCheck
+	err = f.db.Preload("Reviewer").Where("content_id = ?", contentID).First(&modelFactCheck).Error
+	if err != nil {
+		code = "[REPOSITORY] GetFactCheckByContentID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return toFactCheckEntity(&modelFactCheck), nil
+}
+
+// SaveFactCheck implements FactCheckRepository. A content has at most one fact
+// check, saving again replaces it. The content's is_valid follows the rating.
+func (f *factCheckRepository) SaveFactCheck(ctx context.Context, req entity.FactCheckEntity) error {
+	evidenceLinks, err := json.Marshal(req.EvidenceLinks)
+	if err != nil {
+		code = "[REPOSITORY] SaveFactCheck - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	now := time.Now()
+	modelFactCheck := model.FactCheck{
+		ContentID:     req.ContentID,
+		Claim:         req.Claim,
+		Claimant:      req.Claimant,
+		Rating:        req.Rating,
+		ReviewedAt:    req.ReviewedAt,
+		EvidenceLinks: string(evidenceLinks),
+		CreatedAt:     now,
+		UpdatedAt:     &now,
+	}
+	if req.ReviewerID > 0 {
+		modelFactCheck.ReviewerID = &req.ReviewerID
+	}
+
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Reviewer").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "content_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"claim", "claimant", "rating", "reviewer_id", "reviewed_at", "evidence_links", "updated_at"}),
+		}).Create(&modelFactCheck).Error
+		if err != nil {
+			code = "[REPOSITORY] SaveFactCheck - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Model(&model.Content{}).Where("id = ?", req.ContentID).
+			Update("is_valid", entity.FactCheckRatingValidity[req.Rating]).Error
+		if err != nil {
+			code = "[REPOSITORY] SaveFactCheck - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// DeleteFactCheck implements FactCheckRepository. Without a verdict the
+// content is VALID again, like every unchecked content.
+func (f *factCheckRepository) DeleteFactCheck(ctx context.Context, contentID int64) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("content_id = ?", contentID).Delete(&model.FactCheck{})
+		if result.Error != nil {
+			code = "[REPOSITORY] DeleteFactCheck - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Model(&model.Content{}).Where("id = ?", contentID).
+			Update("is_valid", entity.ContentValidityValid).Error
+		if err != nil {
+			code = "[REPOSITORY] DeleteFactCheck - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+func toFactCheckEntity(modelFactCheck *model.FactCheck) *entity.FactCheckEntity {
+	if modelFactCheck == nil {
+		return nil
+	}
+
+	evidenceLinks := []string{}
+	if err := json.Unmarshal([]byte(modelFactCheck.EvidenceLinks), &evidenceLinks); err != nil {
+		code = "[REPOSITORY] toFactCheckEntity - 1"
+		log.Errorw(code, err)
+	}
+
+	resp := entity.FactCheckEntity{
+		ID:            modelFactCheck.ID,
+		ContentID:     modelFactCheck.ContentID,
+		Claim:         modelFactCheck.Claim,
+		Claimant:      modelFactCheck.Claimant,
+		Rating:        modelFactCheck.Rating,
+		ReviewedAt:    modelFactCheck.ReviewedAt,
+		EvidenceLinks: evidenceLinks,
+		CreatedAt:     modelFactCheck.CreatedAt,
+		UpdatedAt:     modelFactCheck.UpdatedAt,
+	}
+	if modelFactCheck.ReviewerID != nil {
+		resp.ReviewerID = *modelFactCheck.ReviewerID
+	}
+	if modelFactCheck.Reviewer != nil {
+		resp.Reviewer = &entity.UserEntity{
+			ID:   modelFactCheck.Reviewer.ID,
+			Name: modelFactCheck.Reviewer.Name,
+		}
+	}
+
+	return &resp
+}
+
+func NewFactCheckRepository(db *gorm.DB) FactCheckRepository {
+	return &factCheckRepository{db: db}
+}
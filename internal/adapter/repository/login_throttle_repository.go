@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type LoginThrottleRepository interface {
+	GetLockedUntil(ctx context.Context, scope string, identifier string) (*time.Time, error)
+	RegisterFailure(ctx context.Context, scope string, identifier string, window time.Duration) (int, error)
+	LockLogin(ctx context.Context, scope string, identifier string, until time.Time) error
+	ClearFailures(ctx context.Context, scope string, identifier string) error
+
+	CreateLockoutEvent(ctx context.Context, req entity.LockoutEventEntity) error
+	GetLockoutEvents(ctx context.Context, query entity.QueryString, scope string) ([]entity.LockoutEventEntity, int64, int64, error)
+}
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+// GetLockedUntil implements LoginThrottleRepository. It returns nil when the
+// identifier has never failed a login or is not locked right now.
+func (l *loginThrottleRepository) GetLockedUntil(ctx context.Context, scope string, identifier string) (*time.Time, error) {
+	var modelThrottle model.LoginThrottle
+	err = l.db.Where("scope = ? AND identifier = ?", scope, identifier).First(&modelThrottle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		code = "[REPOSITORY] GetLockedUntil - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if modelThrottle.LockedUntil == nil || !modelThrottle.LockedUntil.After(time.Now()) {
+		return nil, nil
+	}
+
+	return modelThrottle.LockedUntil, nil
+}
+
+// RegisterFailure implements LoginThrottleRepository. The counter is bumped in
+// a single upsert so concurrent attempts cannot lose increments, and it starts
+// over when the previous failure is older than the window.
+func (l *loginThrottleRepository) RegisterFailure(ctx context.Context, scope string, identifier string, window time.Duration) (int, error) {
+	now := time.Now()
+	var failures int
+	err = l.db.Raw(`INSERT INTO login_throttles (scope, identifier, failures, last_failure_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (scope, identifier) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures`, scope, identifier, now, now.Add(-window)).Scan(&failures).Error
+	if err != nil {
+		code = "[REPOSITORY] RegisterFailure - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// LockLogin implements LoginThrottleRepository.
+func (l *loginThrottleRepository) LockLogin(ctx context.Context, scope string, identifier string, until time.Time) error {
+	err = l.db.Model(&model.LoginThrottle{}).
+		Where("scope = ? AND identifier = ?", scope, identifier).
+		Update("locked_until", until).Error
+	if err != nil {
+		code = "[REPOSITORY] LockLogin - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// ClearFailures implements LoginThrottleRepository.
+func (l *loginThrottleRepository) ClearFailures(ctx context.Context, scope string, identifier string) error {
+	err = l.db.Where("scope = ? AND identifier = ?", scope, identifier).Delete(&model.LoginThrottle{}).Error
+	if err != nil {
+		code = "[REPOSITORY] ClearFailures - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateLockoutEvent implements LoginThrottleRepository.
+func (l *loginThrottleRepository) CreateLockoutEvent(ctx context.Context, req entity.LockoutEventEntity) error {
+	modelEvent := model.LockoutEvent{
+		Scope:       req.Scope,
+		Identifier:  req.Identifier,
+		UserID:      req.UserID,
+		IPAddress:   req.IPAddress,
+		Failures:    req.Failures,
+		LockedUntil: req.LockedUntil,
+		CreatedAt:   time.Now(),
+	}
+
+	err = l.db.Create(&modelEvent).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateLockoutEvent - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetLockoutEvents implements LoginThrottleRepository.
+func (l *loginThrottleRepository) GetLockoutEvents(ctx context.Context, query entity.QueryString, scope string) ([]entity.LockoutEventEntity, int64, int64, error) {
+	var modelEvents []model.LockoutEvent
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := l.db.Model(&model.LockoutEvent{})
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("identifier ilike ? OR ip_address ilike ?", "%"+query.Search+"%", "%"+query.Search+"%")
+	}
+
+	if scope != "" {
+		sqlMain = sqlMain.Where("scope = ?", scope)
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetLockoutEvents - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("created_at DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelEvents).Error
+	if err != nil {
+		code = "[REPOSITORY] GetLockoutEvents - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.LockoutEventEntity{}
+	for _, val := range modelEvents {
+		resps = append(resps, entity.LockoutEventEntity{
+			ID:          val.ID,
+			Scope:       val.Scope,
+			Identifier:  val.Identifier,
+			UserID:      val.UserID,
+			IPAddress:   val.IPAddress,
+			Failures:    val.Failures,
+			LockedUntil: val.LockedUntil,
+			CreatedAt:   val.CreatedAt,
+		})
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// slugTable names the table owning a slug column and the table keeping the
+// slugs it had before. Fallback is used when a title leaves no slug at all.
+type slugTable struct {
+	Table        string
+	History      string
+	HistoryOwner string
+	Fallback     string
+}
+
+var (
+	contentSlugs  = slugTable{Table: "contents", History: "content_slug_histories", HistoryOwner: "content_id", Fallback: "content"}
+	categorySlugs = slugTable{Table: "categories", History: "category_slug_histories", HistoryOwner: "category_id", Fallback: "category"}
+)
+
+// uniqueSlug returns base, or base with the lowest free numeric suffix, so it
+// is neither the current nor an old slug of another row than id. A row keeps
+// its own slug.
+func uniqueSlug(tx *gorm.DB, t slugTable, base string, id int64) (string, error) {
+	if base == "" {
+		base = t.Fallback
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		var count int64
+		err := tx.Table(t.Table).Where("slug = ? AND id <> ?", slug, id).Count(&count).Error
+		if err != nil {
+			code = "[REPOSITORY] uniqueSlug - 1"
+			log.Errorw(code, err)
+			return "", err
+		}
+
+		if count == 0 {
+			err = tx.Table(t.History).Where("slug = ? AND "+t.HistoryOwner+" <> ?", slug, id).Count(&count).Error
+			if err != nil {
+				code = "[REPOSITORY] uniqueSlug - 2"
+				log.Errorw(code, err)
+				return "", err
+			}
+		}
+
+		if count == 0 {
+			return slug, nil
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// recordSlugChange keeps oldSlug in the history of id. A row taking back one
+// of its old slugs drops it from the history again.
+func recordSlugChange(tx *gorm.DB, t slugTable, id int64, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	err := tx.Exec("DELETE FROM "+t.History+" WHERE "+t.HistoryOwner+" = ? AND slug = ?", id, newSlug).Error
+	if err != nil {
+		code = "[REPOSITORY] recordSlugChange - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = tx.Table(t.History).Clauses(clause.OnConflict{DoNothing: true}).Create(map[string]interface{}{
+		t.HistoryOwner: id,
+		"slug":         oldSlug,
+	}).Error
+	if err != nil {
+		code = "[REPOSITORY] recordSlugChange - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// slugOwner finds the row currently or previously known under slug.
+func slugOwner(db *gorm.DB, t slugTable, slug string) (int64, error) {
+	var ids []int64
+	err := db.Table(t.Table).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error
+	if err != nil {
+		code = "[REPOSITORY] slugOwner - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		err = db.Table(t.History).Where("slug = ?", slug).Limit(1).Pluck(t.HistoryOwner, &ids).Error
+		if err != nil {
+			code = "[REPOSITORY] slugOwner - 2"
+			log.Errorw(code, err)
+			return 0, err
+		}
+	}
+
+	if len(ids) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return ids[0], nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// suggestionMatch matches a text that starts with the query, has a word that
+// starts with it, or has a word similar to it (pg_trgm word similarity, so
+// typos are forgiven). The placeholders take both suggestionPatterns and the
+// text itself.
+const suggestionMatch = "(%[1]s ILIKE ? OR %[1]s ILIKE ? OR ? <%% %[1]s)"
+
+// contentPopularity ranks titles by views and lets them decay with age, a new
+// article with a few views can beat an old one with many.
+const contentPopularity = "(view_count + 1) / power(extract(epoch FROM now() - COALESCE(publish_at, created_at)) / 86400 + 2, 1.5)"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SuggestionRepository interface {
+	GetSuggestions(ctx context.Context, text string, limit int) (*entity.SuggestionEntity, error)
+}
+
+type suggestionRepository struct {
+	db *gorm.DB
+}
+
+// GetSuggestions implements SuggestionRepository. Matches on the start of the
+// text come first, the rest is ordered by popularity.
+func (s *suggestionRepository) GetSuggestions(ctx context.Context, text string, limit int) (*entity.SuggestionEntity, error) {
+	prefix, wordPrefix := suggestionPatterns(text)
+
+	var modelContents []model.Content
+	err = s.db.Model(&model.Content{}).
+		Select("id, title, slug").
+		Where("status = ?", entity.ContentStatusPublished).
+		Where(suggestionCondition("title"), prefix, wordPrefix, text).
+		Order(suggestionOrder("title", prefix, contentPopularity+" DESC")).
+		Limit(limit).
+		Find(&modelContents).Error
+	if err != nil {
+		code = "[REPOSITORY] GetSuggestions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	var modelCategories []model.Category
+	published := s.db.Model(&model.Content{}).
+		Select("count(*)").
+		Where("contents.category_id = categories.id AND contents.status = ?", entity.ContentStatusPublished)
+	err = s.db.Model(&model.Category{}).
+		Select("id, title, slug").
+		Where(suggestionCondition("title"), prefix, wordPrefix, text).
+		Order(suggestionOrder("title", prefix, "(?) DESC, title ASC", published)).
+		Limit(limit).
+		Find(&modelCategories).Error
+	if err != nil {
+		code = "[REPOSITORY] GetSuggestions - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	// usage_count counts drafts as well, a tag only shows up once it is on a
+	// published content
+	var modelTags []model.Tag
+	tagged := func(selects string) *gorm.DB {
+		return s.db.Table("content_tags").
+			Select(selects).
+			Joins("JOIN contents ON contents.id = content_tags.content_id").
+			Where("content_tags.tag_id = tags.id AND contents.status = ?", entity.ContentStatusPublished)
+	}
+	err = s.db.Model(&model.Tag{}).
+		Where("EXISTS (?)", tagged("1")).
+		Where(suggestionCondition("name"), prefix, wordPrefix, text).
+		Order(suggestionOrder("name", prefix, "(?) DESC, name ASC", tagged("count(*)"))).
+		Limit(limit).
+		Find(&modelTags).Error
+	if err != nil {
+		code = "[REPOSITORY] GetSuggestions - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	result := entity.SuggestionEntity{
+		Contents:   []entity.ContentEntity{},
+		Categories: []entity.CategoryEntity{},
+		Tags:       []entity.TagEntity{},
+	}
+	for _, val := range modelContents {
+		result.Contents = append(result.Contents, entity.ContentEntity{ID: val.ID, Title: val.Title, Slug: val.Slug})
+	}
+	for _, val := range modelCategories {
+		result.Categories = append(result.Categories, entity.CategoryEntity{ID: val.ID, Title: val.Title, Slug: val.Slug})
+	}
+	for _, val := range modelTags {
+		result.Tags = append(result.Tags, toTagEntity(val))
+	}
+
+	return &result, nil
+}
+
+// suggestionPatterns returns the ILIKE patterns for text at the start of a
+// column and at the start of any word in it.
+func suggestionPatterns(text string) (string, string) {
+	escaped := likeEscaper.Replace(text)
+	return escaped + "%", "% " + escaped + "%"
+}
+
+func suggestionCondition(column string) string {
+	return fmt.Sprintf(suggestionMatch, column)
+}
+
+// suggestionOrder puts the rows starting with prefix first and orders the rest
+// by then.
+func suggestionOrder(column, prefix, then string, vars ...interface{}) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                column + " ILIKE ? DESC, " + then,
+		Vars:               append([]interface{}{prefix}, vars...),
+		WithoutParentheses: true,
+	}}
+}
+
+func NewSuggestionRepository(db *gorm.DB) SuggestionRepository {
+	return &suggestionRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrTagSlugTaken = errors.New("Another Tag Already Has This Name, Merge The Tags Instead")
+
+// legacyTagSlug matches the placeholder slugs the tags migration gives the tags
+// of the old column.
+const legacyTagSlug = "slug ~ '^legacy-[0-9a-f]{32}$'"
+
+type TagRepository interface {
+	GetTags(ctx context.Context, query entity.QueryString) ([]entity.TagEntity, int64, int64, error)
+	GetTagByID(ctx context.Context, id int64) (*entity.TagEntity, error)
+	GetTagBySlug(ctx context.Context, slug string) (*entity.TagEntity, error)
+	RenameTag(ctx context.Context, req entity.TagEntity, editorID int64) error
+	MergeTags(ctx context.Context, sourceID, targetID int64, editorID int64) error
+	DeleteTag(ctx context.Context, id int64, editorID int64) error
+	SlugTag(ctx context.Context, id int64, slug string) (int64, error)
+	CountLegacyTags(ctx context.Context) (int64, error)
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// GetTags implements TagRepository. The most used tags come first.
+func (t *tagRepository) GetTags(ctx context.Context, query entity.QueryString) ([]entity.TagEntity, int64, int64, error) {
+	var modelTags []model.Tag
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := t.db.Model(&model.Tag{})
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("name ilike ?", "%"+query.Search+"%")
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code = "[REPOSITORY] GetTags - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("usage_count DESC, name ASC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelTags).Error
+	if err != nil {
+		code = "[REPOSITORY] GetTags - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.TagEntity{}
+	for _, val := range modelTags {
+		resps = append(resps, toTagEntity(val))
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// GetTagByID implements TagRepository.
+func (t *tagRepository) GetTagByID(ctx context.Context, id int64) (*entity.TagEntity, error) {
+	var modelTag model.Tag
+	err = t.db.Where("id = ?", id).First(&modelTag).Error
+	if err != nil {
+		code = "[REPOSITORY] GetTagByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toTagEntity(modelTag)
+	return &resp, nil
+}
+
+// GetTagBySlug implements TagRepository.
+func (t *tagRepository) GetTagBySlug(ctx context.Context, slug string) (*entity.TagEntity, error) {
+	var modelTag model.Tag
+	err = t.db.Where("slug = ?", slug).First(&modelTag).Error
+	if err != nil {
+		code = "[REPOSITORY] GetTagBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp := toTagEntity(modelTag)
+	return &resp, nil
+}
+
+// RenameTag implements TagRepository. A name whose slug belongs to another tag
+// is refused with ErrTagSlugTaken, those tags have to be merged.
+func (t *tagRepository) RenameTag(ctx context.Context, req entity.TagEntity, editorID int64) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&model.Tag{}).Where("slug = ? AND id <> ?", req.Slug, req.ID).Count(&count).Error
+		if err != nil {
+			code = "[REPOSITORY] RenameTag - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if count > 0 {
+			return ErrTagSlugTaken
+		}
+
+		result := tx.Model(&model.Tag{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+			"name":       req.Name,
+			"slug":       req.Slug,
+			"updated_at": time.Now(),
+		})
+		if result.Error != nil {
+			code = "[REPOSITORY] RenameTag - 2"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		contentIDs, err := taggedContentIDs(tx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		return reviseContents(tx, contentIDs, editorID)
+	})
+}
+
+// MergeTags implements TagRepository. The contents of source get target, then
+// source is deleted.
+func (t *tagRepository) MergeTags(ctx context.Context, sourceID, targetID int64, editorID int64) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		contentIDs, err := taggedContentIDs(tx, sourceID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`INSERT INTO content_tags (content_id, tag_id, position)
+			SELECT content_id, ?, position FROM content_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			code = "[REPOSITORY] MergeTags - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("id = ?", sourceID).Delete(&model.Tag{}).Error
+		if err != nil {
+			code = "[REPOSITORY] MergeTags - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recountTagUsage(tx, []int64{targetID})
+		if err != nil {
+			code = "[REPOSITORY] MergeTags - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return reviseContents(tx, contentIDs, editorID)
+	})
+}
+
+// DeleteTag implements TagRepository. The tag is removed from every content.
+func (t *tagRepository) DeleteTag(ctx context.Context, id int64, editorID int64) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		contentIDs, err := taggedContentIDs(tx, id)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&model.Tag{})
+		if result.Error != nil {
+			code = "[REPOSITORY] DeleteTag - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return reviseContents(tx, contentIDs, editorID)
+	})
+}
+
+// SlugTag implements TagRepository. It is used to slug the tags migrated from
+// the old column. A tag whose slug belongs to another tag already is merged
+// into it, a tag without a slug gets a free "tag-N" fallback. It returns the
+// id of the tag holding the slug.
+func (t *tagRepository) SlugTag(ctx context.Context, id int64, slug string) (int64, error) {
+	targetID := id
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		if slug == "" {
+			var slugs []string
+			err := tx.Model(&model.Tag{}).Where("(slug = 'tag' OR slug LIKE 'tag-%') AND id <> ?", id).Pluck("slug", &slugs).Error
+			if err != nil {
+				code = "[REPOSITORY] SlugTag - 1"
+				log.Errorw(code, err)
+				return err
+			}
+
+			slug = "tag"
+			for n := 2; slices.Contains(slugs, slug); n++ {
+				slug = fmt.Sprintf("tag-%d", n)
+			}
+		} else {
+			var other model.Tag
+			err := tx.Where("slug = ? AND id <> ?", slug, id).Limit(1).Find(&other).Error
+			if err != nil {
+				code = "[REPOSITORY] SlugTag - 2"
+				log.Errorw(code, err)
+				return err
+			}
+
+			if other.ID > 0 {
+				targetID = other.ID
+			}
+		}
+
+		if targetID == id {
+			err := tx.Model(&model.Tag{}).Where("id = ?", id).Update("slug", slug).Error
+			if err != nil {
+				code = "[REPOSITORY] SlugTag - 3"
+				log.Errorw(code, err)
+				return err
+			}
+
+			return nil
+		}
+
+		err := tx.Exec(`INSERT INTO content_tags (content_id, tag_id, position)
+			SELECT content_id, ?, position FROM content_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, id).Error
+		if err != nil {
+			code = "[REPOSITORY] SlugTag - 4"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("id = ?", id).Delete(&model.Tag{}).Error
+		if err != nil {
+			code = "[REPOSITORY] SlugTag - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = recountTagUsage(tx, []int64{targetID})
+		if err != nil {
+			code = "[REPOSITORY] SlugTag - 6"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return targetID, nil
+}
+
+// replaceContentTags links the content to tags in the given order. Tags are
+// found by slug and created on first use, usage counts follow along.
+func replaceContentTags(tx *gorm.DB, contentID int64, tags []entity.TagEntity) error {
+	var tagIDs []int64
+	err := tx.Model(&model.ContentTag{}).Where("content_id = ?", contentID).Pluck("tag_id", &tagIDs).Error
+	if err != nil {
+		code = "[REPOSITORY] replaceContentTags - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = tx.Where("content_id = ?", contentID).Delete(&model.ContentTag{}).Error
+	if err != nil {
+		code = "[REPOSITORY] replaceContentTags - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	linked := map[int64]bool{}
+	for _, tag := range tags {
+		modelTag := model.Tag{Name: tag.Name, Slug: tag.Slug}
+		err = tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).Create(&modelTag).Error
+		if err != nil {
+			code = "[REPOSITORY] replaceContentTags - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		// the tag existed already
+		if modelTag.ID == 0 {
+			err = tx.Where("slug = ?", tag.Slug).First(&modelTag).Error
+			if err != nil {
+				code = "[REPOSITORY] replaceContentTags - 4"
+				log.Errorw(code, err)
+				return err
+			}
+		}
+
+		if linked[modelTag.ID] {
+			continue
+		}
+
+		err = tx.Create(&model.ContentTag{ContentID: contentID, TagID: modelTag.ID, Position: len(linked)}).Error
+		if err != nil {
+			code = "[REPOSITORY] replaceContentTags - 5"
+			log.Errorw(code, err)
+			return err
+		}
+
+		linked[modelTag.ID] = true
+		tagIDs = append(tagIDs, modelTag.ID)
+	}
+
+	return recountTagUsage(tx, tagIDs)
+}
+
+func recountTagUsage(tx *gorm.DB, tagIDs []int64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	return tx.Exec("UPDATE tags SET usage_count = (SELECT COUNT(*) FROM content_tags WHERE tag_id = tags.id) WHERE id IN ?", tagIDs).Error
+}
+
+func taggedContentIDs(tx *gorm.DB, tagID int64) ([]int64, error) {
+	var contentIDs []int64
+	err := tx.Model(&model.ContentTag{}).Where("tag_id = ?", tagID).Pluck("content_id", &contentIDs).Error
+	if err != nil {
+		code = "[REPOSITORY] taggedContentIDs - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return contentIDs, nil
+}
+
+// reviseContents writes a revision for each content whose tags were changed
+// through tag management, so the change shows up in the content history.
+func reviseContents(tx *gorm.DB, contentIDs []int64, editorID int64) error {
+	for _, contentID := range contentIDs {
+		_, err = createContentRevision(tx, contentID, editorID, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadContentTags returns the tags of each content in their order.
+func loadContentTags(db *gorm.DB, contentIDs []int64) (map[int64][]entity.TagEntity, error) {
+	var rows []struct {
+		ContentID  int64
+		ID         int64
+		Name       string
+		Slug       string
+		UsageCount int
+	}
+
+	tags := map[int64][]entity.TagEntity{}
+	if len(contentIDs) == 0 {
+		return tags, nil
+	}
+
+	err := db.Table("content_tags").
+		Select("content_tags.content_id, tags.id, tags.name, tags.slug, tags.usage_count").
+		Joins("JOIN tags ON tags.id = content_tags.tag_id").
+		Where("content_tags.content_id IN ?", contentIDs).
+		Order("content_tags.content_id, content_tags.position").
+		Scan(&rows).Error
+	if err != nil {
+		code = "[REPOSITORY] loadContentTags - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		tags[row.ContentID] = append(tags[row.ContentID], entity.TagEntity{
+			ID:         row.ID,
+			Name:       row.Name,
+			Slug:       row.Slug,
+			UsageCount: row.UsageCount,
+		})
+	}
+
+	return tags, nil
+}
+
+func toTagEntity(modelTag model.Tag) entity.TagEntity {
+	return entity.TagEntity{
+		ID:         modelTag.ID,
+		Name:       modelTag.Name,
+		Slug:       modelTag.Slug,
+		UsageCount: modelTag.UsageCount,
+		CreatedAt:  modelTag.CreatedAt,
+	}
+}
+
+// CountLegacyTags implements TagRepository. It counts the tags still holding the
+// placeholder slug of the tags migration.
+func (t *tagRepository) CountLegacyTags(ctx context.Context) (int64, error) {
+	var count int64
+	err := t.db.Model(&model.Tag{}).Where(legacyTagSlug).Count(&count).Error
+	if err != nil {
+		code = "[REPOSITORY] CountLegacyTags - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/domain/model"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactorEntity, error)
+	SaveTwoFactorSecret(ctx context.Context, userID int64, secret string, recoveryHashes []string) error
+	EnableTwoFactor(ctx context.Context, userID int64, step int64) error
+	DisableTwoFactor(ctx context.Context, userID int64) error
+	UseTotpStep(ctx context.Context, userID int64, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+
+	CreateMfaChallenge(ctx context.Context, req entity.MfaChallengeEntity) error
+	GetMfaChallengeByHash(ctx context.Context, tokenHash string) (*entity.MfaChallengeEntity, error)
+	IncrementMfaChallengeAttempts(ctx context.Context, id int64) error
+	MarkMfaChallengeUsed(ctx context.Context, id int64) (bool, error)
+
+	GetRequiredRoles(ctx context.Context) ([]string, error)
+	SetRequiredRoles(ctx context.Context, roles []string) error
+	IsTwoFactorRequired(ctx context.Context, role string) (bool, error)
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+// GetTwoFactor implements TwoFactorRepository.
+func (t *twoFactorRepository) GetTwoFactor(ctx context.Context, userID int64) (*entity.TwoFactorEntity, error) {
+	var modelUser model.User
+	err = t.db.Select("id", "totp_secret", "totp_enabled", "totp_last_step").Where("id = ?", userID).First(&modelUser).Error
+	if err != nil {
+		code = "[REPOSITORY] GetTwoFactor - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.TwoFactorEntity{
+		UserID:   modelUser.ID,
+		Secret:   modelUser.TotpSecret,
+		Enabled:  modelUser.TotpEnabled,
+		LastStep: modelUser.TotpLastStep,
+	}, nil
+}
+
+// SaveTwoFactorSecret implements TwoFactorRepository. The secret stays pending
+// (totp_enabled false) until the user confirms it with a first code.
+func (t *twoFactorRepository) SaveTwoFactorSecret(ctx context.Context, userID int64, secret string, recoveryHashes []string) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"totp_secret":    secret,
+			"totp_enabled":   false,
+			"totp_last_step": 0,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] SaveTwoFactorSecret - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+		if err != nil {
+			code = "[REPOSITORY] SaveTwoFactorSecret - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		modelCodes := []model.RecoveryCode{}
+		for _, hash := range recoveryHashes {
+			modelCodes = append(modelCodes, model.RecoveryCode{
+				UserID:   userID,
+				CodeHash: hash,
+			})
+		}
+
+		if len(modelCodes) == 0 {
+			return nil
+		}
+
+		err = tx.Create(&modelCodes).Error
+		if err != nil {
+			code = "[REPOSITORY] SaveTwoFactorSecret - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// EnableTwoFactor implements TwoFactorRepository.
+func (t *twoFactorRepository) EnableTwoFactor(ctx context.Context, userID int64, step int64) error {
+	err = t.db.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"totp_enabled":   true,
+		"totp_last_step": step,
+	}).Error
+	if err != nil {
+		code = "[REPOSITORY] EnableTwoFactor - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// DisableTwoFactor implements TwoFactorRepository.
+func (t *twoFactorRepository) DisableTwoFactor(ctx context.Context, userID int64) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"totp_secret":    "",
+			"totp_enabled":   false,
+			"totp_last_step": 0,
+		}).Error
+		if err != nil {
+			code = "[REPOSITORY] DisableTwoFactor - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		err = tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+		if err != nil {
+			code = "[REPOSITORY] DisableTwoFactor - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// UseTotpStep implements TwoFactorRepository. It returns false when a code of
+// the same or a later time step was already accepted, which blocks replays.
+func (t *twoFactorRepository) UseTotpStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	result := t.db.Model(&model.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		code = "[REPOSITORY] UseTotpStep - 1"
+		log.Errorw(code, result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode implements TwoFactorRepository.
+func (t *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	result := t.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		code = "[REPOSITORY] UseRecoveryCode - 1"
+		log.Errorw(code, result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CreateMfaChallenge implements TwoFactorRepository.
+func (t *twoFactorRepository) CreateMfaChallenge(ctx context.Context, req entity.MfaChallengeEntity) error {
+	modelChallenge := model.MfaChallenge{
+		UserID:    req.UserID,
+		TokenHash: req.TokenHash,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	err = t.db.Create(&modelChallenge).Error
+	if err != nil {
+		code = "[REPOSITORY] CreateMfaChallenge - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetMfaChallengeByHash implements TwoFactorRepository.
+func (t *twoFactorRepository) GetMfaChallengeByHash(ctx context.Context, tokenHash string) (*entity.MfaChallengeEntity, error) {
+	var modelChallenge model.MfaChallenge
+	err = t.db.Where("token_hash = ?", tokenHash).First(&modelChallenge).Error
+	if err != nil {
+		code = "[REPOSITORY] GetMfaChallengeByHash - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.MfaChallengeEntity{
+		ID:        modelChallenge.ID,
+		UserID:    modelChallenge.UserID,
+		TokenHash: modelChallenge.TokenHash,
+		Attempts:  modelChallenge.Attempts,
+		ExpiresAt: modelChallenge.ExpiresAt,
+		UsedAt:    modelChallenge.UsedAt,
+	}, nil
+}
+
+// IncrementMfaChallengeAttempts implements TwoFactorRepository.
+func (t *twoFactorRepository) IncrementMfaChallengeAttempts(ctx context.Context, id int64) error {
+	err = t.db.Model(&model.MfaChallenge{}).Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+	if err != nil {
+		code = "[REPOSITORY] IncrementMfaChallengeAttempts - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// MarkMfaChallengeUsed implements TwoFactorRepository.
+func (t *twoFactorRepository) MarkMfaChallengeUsed(ctx context.Context, id int64) (bool, error) {
+	result := t.db.Model(&model.MfaChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		code = "[REPOSITORY] MarkMfaChallengeUsed - 1"
+		log.Errorw(code, result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// GetRequiredRoles implements TwoFactorRepository.
+func (t *twoFactorRepository) GetRequiredRoles(ctx context.Context) ([]string, error) {
+	roles := []string{}
+	err = t.db.Model(&model.TwoFactorRequiredRole{}).Order("role").Pluck("role", &roles).Error
+	if err != nil {
+		code = "[REPOSITORY] GetRequiredRoles - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// SetRequiredRoles implements TwoFactorRepository.
+func (t *twoFactorRepository) SetRequiredRoles(ctx context.Context, roles []string) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("1 = 1").Delete(&model.TwoFactorRequiredRole{}).Error
+		if err != nil {
+			code = "[REPOSITORY] SetRequiredRoles - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		modelRoles := []model.TwoFactorRequiredRole{}
+		for _, role := range roles {
+			modelRoles = append(modelRoles, model.TwoFactorRequiredRole{Role: role})
+		}
+
+		if len(modelRoles) == 0 {
+			return nil
+		}
+
+		err = tx.Create(&modelRoles).Error
+		if err != nil {
+			code = "[REPOSITORY] SetRequiredRoles - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// IsTwoFactorRequired implements TwoFactorRepository.
+func (t *twoFactorRepository) IsTwoFactorRequired(ctx context.Context, role string) (bool, error) {
+	var count int64
+	err = t.db.Model(&model.TwoFactorRequiredRole{}).Where("role = ?", role).Count(&count).Error
+	if err != nil {
+		code = "[REPOSITORY] IsTwoFactorRequired - 1"
+		log.Errorw(code, err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
@@ -2,16 +2,30 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"math"
+	"time"
 	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/domain/model"
 
 	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+var ErrEmailAlreadyUsed = errors.New("Email Already Registered")
+
+// uniqueViolation is the postgres error code of a unique index violation.
+const uniqueViolation = "23505"
+
 type UserRepository interface {
 	UpdatePassword(ctx context.Context, newPass string, id int64) error
 	GetUserByID(ctx context.Context, id int64) (*entity.UserEntity, error)
+
+	GetUsers(ctx context.Context, query entity.QueryString) ([]entity.UserEntity, int64, int64, error)
+	CreateUser(ctx context.Context, req entity.UserEntity) (int64, error)
+	UpdateUser(ctx context.Context, req entity.UserEntity) error
+	SetUserActive(ctx context.Context, id int64, active bool) error
 }
 
 type userRepository struct {
@@ -32,6 +46,10 @@ func (u *userRepository) GetUserByID(ctx context.Context, id int64) (*entity.Use
 		ID: id,
 		Name: modelUser.Name,
 		Email: modelUser.Email,
+		Role: modelUser.Role,
+		IsActive: modelUser.IsActive,
+		TwoFactorEnabled: modelUser.TotpEnabled,
+		CreatedAt: modelUser.CreatedAt,
 	}, nil
 }
 
@@ -47,6 +65,155 @@ func (u *userRepository) UpdatePassword(ctx context.Context, newPass string, id
 	return nil
 }
 
+// GetUsers implements UserRepository.
+func (u *userRepository) GetUsers(ctx context.Context, query entity.QueryString) ([]entity.UserEntity, int64, int64, error) {
+	var modelUsers []model.User
+	var countData int64
+
+	offset := (query.Page - 1) * query.Limit
+	sqlMain := u.db.Model(&model.User{})
+	if query.Search != "" {
+		sqlMain = sqlMain.Where("name ilike ? OR email ilike ?", "%"+query.Search+"%", "%"+query.Search+"%")
+	}
+
+	err = sqlMain.Count(&countData).Error
+	if err != nil {
+		code := "[REPOSITORY] GetUsers - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	totalPages := int(math.Ceil(float64(countData) / float64(query.Limit)))
+
+	err = sqlMain.
+		Order("created_at DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&modelUsers).Error
+	if err != nil {
+		code := "[REPOSITORY] GetUsers - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	resps := []entity.UserEntity{}
+	for _, val := range modelUsers {
+		resps = append(resps, entity.UserEntity{
+			ID: val.ID,
+			Name: val.Name,
+			Email: val.Email,
+			Role: val.Role,
+			IsActive: val.IsActive,
+			TwoFactorEnabled: val.TotpEnabled,
+			CreatedAt: val.CreatedAt,
+		})
+	}
+
+	return resps, countData, int64(totalPages), nil
+}
+
+// CreateUser implements UserRepository.
+func (u *userRepository) CreateUser(ctx context.Context, req entity.UserEntity) (int64, error) {
+	modelUser := model.User{
+		Name: req.Name,
+		Email: req.Email,
+		Password: req.Password,
+		Role: req.Role,
+		IsActive: true,
+	}
+
+	err = u.db.Create(&modelUser).Error
+	if err != nil {
+		code := "[REPOSITORY] CreateUser - 1"
+		log.Errorw(code, err)
+		if isEmailTaken(err) {
+			return 0, ErrEmailAlreadyUsed
+		}
+		return 0, err
+	}
+
+	return modelUser.ID, nil
+}
+
+// UpdateUser implements UserRepository.
+func (u *userRepository) UpdateUser(ctx context.Context, req entity.UserEntity) error {
+	modelUser := model.User{
+		Name: req.Name,
+		Email: req.Email,
+		Role: req.Role,
+	}
+
+	result := u.db.Where("id = ?", req.ID).Updates(&modelUser)
+	if result.Error != nil {
+		code := "[REPOSITORY] UpdateUser - 1"
+		log.Errorw(code, result.Error)
+		if isEmailTaken(result.Error) {
+			return ErrEmailAlreadyUsed
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// isEmailTaken tells a unique violation of the email indexes apart from other
+// errors. The indexes decide, a check before the write would race another
+// request with the same email.
+func isEmailTaken(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return false
+	}
+
+	return pgErr.ConstraintName == "users_email_key" || pgErr.ConstraintName == "idx_users_email_lower"
+}
+
+// SetUserActive implements UserRepository. Deactivating a user also revokes
+// every refresh token the user still holds.
+func (u *userRepository) SetUserActive(ctx context.Context, id int64, active bool) error {
+	now := time.Now()
+	values := map[string]interface{}{
+		"is_active": active,
+		"deactivated_at": nil,
+		"updated_at": now,
+	}
+	if !active {
+		values["deactivated_at"] = now
+	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.User{}).Where("id = ?", id).Updates(values)
+		if result.Error != nil {
+			code := "[REPOSITORY] SetUserActive - 1"
+			log.Errorw(code, result.Error)
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if active {
+			return nil
+		}
+
+		err := tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error
+		if err != nil {
+			code := "[REPOSITORY] SetUserActive - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		return nil
+	})
+}
+
 func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
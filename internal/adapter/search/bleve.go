@@ -0,0 +1,516 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"html"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/sorting"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	htmlHighlighter "github.com/blevesearch/bleve/v2/search/highlight/highlighter/html"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// reindexBatchSize is the number of contents loaded and indexed at once.
+const reindexBatchSize = 200
+
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// bleveSearch keeps an embedded index on local disk. Only one process can open
+// it, the reindex command has to run while the server is stopped.
+type bleveSearch struct {
+	index        bleve.Index
+	path         string
+	fuzziness    int
+	facetSize    int
+	contentRepo  repository.ContentRepository
+	categoryRepo repository.CategoryRepository
+}
+
+func (b *bleveSearch) Search(ctx context.Context, req entity.SearchQueryEntity) (*entity.SearchResultEntity, error) {
+	conjuncts := []query.Query{}
+	if req.Query != "" {
+		conjuncts = append(conjuncts, b.textQuery(req.Query))
+	}
+
+	// a category matches the contents of its subcategories as well, like the
+	// postgres search
+	filters := map[string]string{
+		"status":        req.Status,
+		"category_path": req.CategorySlug,
+		"tag":           req.TagSlug,
+	}
+	if req.AuthorID > 0 {
+		filters["author"] = strconv.FormatInt(req.AuthorID, 10)
+	}
+
+	for field, value := range filters {
+		if value == "" {
+			continue
+		}
+
+		term := bleve.NewTermQuery(value)
+		term.SetField(field)
+		conjuncts = append(conjuncts, term)
+	}
+
+	// a zero time leaves that end of the range open
+	if req.From != nil || req.To != nil {
+		var start, end time.Time
+		if req.From != nil {
+			start = *req.From
+		}
+		if req.To != nil {
+			end = *req.To
+		}
+
+		inclusive := true
+		dateRange := bleve.NewDateRangeInclusiveQuery(start, end, &inclusive, &inclusive)
+		dateRange.SetField("date")
+		conjuncts = append(conjuncts, dateRange)
+	}
+
+	var searchQuery query.Query = bleve.NewMatchAllQuery()
+	if len(conjuncts) > 0 {
+		searchQuery = bleve.NewConjunctionQuery(conjuncts...)
+	}
+
+	searchReq := bleve.NewSearchRequestOptions(searchQuery, req.Limit, (req.Page-1)*req.Limit, false)
+	searchReq.Fields = []string{"*"}
+	if req.Query != "" {
+		searchReq.Highlight = bleve.NewHighlightWithStyle(htmlHighlighter.Name)
+		searchReq.Highlight.AddField("title")
+		searchReq.Highlight.AddField("description")
+	}
+
+	searchReq.AddFacet(entity.SearchFacetCategory, bleve.NewFacetRequest("category", b.facetSize))
+	searchReq.AddFacet(entity.SearchFacetTag, bleve.NewFacetRequest("tag", b.facetSize))
+	searchReq.AddFacet(entity.SearchFacetAuthor, bleve.NewFacetRequest("author", b.facetSize))
+
+	switch {
+	case req.OrderBy == entity.SearchOrderNewest:
+		searchReq.SortBy([]string{"-date", "-_id"})
+	case req.OrderBy == entity.SearchOrderOldest:
+		searchReq.SortBy([]string{"date", "_id"})
+	case req.Query == "":
+		// without a query every hit scores the same
+		searchReq.SortBy([]string{"-date", "-_id"})
+	}
+
+	result, err := b.index.SearchInContext(ctx, searchReq)
+	if err != nil {
+		code = "[SEARCH] BleveSearch - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	contents := []entity.ContentEntity{}
+	for _, hit := range result.Hits {
+		contents = append(contents, toSearchContentEntity(hit, req.Query != ""))
+	}
+
+	facets := map[string][]entity.SearchFacetEntity{}
+	for name, facet := range result.Facets {
+		terms := []entity.SearchFacetEntity{}
+		if facet.Terms != nil {
+			for _, term := range facet.Terms.Terms() {
+				terms = append(terms, entity.SearchFacetEntity{Term: term.Term, Count: term.Count})
+			}
+		}
+		facets[name] = terms
+	}
+
+	totalPages := int64(0)
+	if req.Limit > 0 {
+		totalPages = int64(math.Ceil(float64(result.Total) / float64(req.Limit)))
+	}
+
+	return &entity.SearchResultEntity{
+		Contents:   contents,
+		TotalData:  int64(result.Total),
+		TotalPages: totalPages,
+		Facets:     facets,
+	}, nil
+}
+
+// textQuery matches the title, excerpt and description with typos tolerated.
+// An exact title match is added on top so it outranks fuzzy ones.
+func (b *bleveSearch) textQuery(text string) query.Query {
+	boosts := map[string]float64{
+		"title":       3,
+		"excerpt":     2,
+		"description": 1,
+	}
+
+	disjuncts := []query.Query{}
+	for field, boost := range boosts {
+		match := bleve.NewMatchQuery(text)
+		match.SetField(field)
+		match.SetBoost(boost)
+		match.SetFuzziness(b.fuzziness)
+		disjuncts = append(disjuncts, match)
+	}
+
+	exact := bleve.NewMatchQuery(text)
+	exact.SetField("title")
+	exact.SetBoost(5)
+	disjuncts = append(disjuncts, exact)
+
+	return bleve.NewDisjunctionQuery(disjuncts...)
+}
+
+func (b *bleveSearch) IndexContent(ctx context.Context, content entity.ContentEntity) {
+	paths := b.categoryPaths(ctx)
+	err := b.index.Index(strconv.FormatInt(content.ID, 10), toContentDocument(content, paths[content.CategoryID]))
+	if err != nil {
+		code = "[SEARCH] BleveIndexContent - 1"
+		log.Errorw(code, err)
+	}
+}
+
+func (b *bleveSearch) RemoveContent(ctx context.Context, id int64) {
+	err := b.index.Delete(strconv.FormatInt(id, 10))
+	if err != nil {
+		code = "[SEARCH] BleveRemoveContent - 1"
+		log.Errorw(code, err)
+	}
+}
+
+// Reindex drops the index and builds it again from every content in the
+// database, contents removed since the last build disappear with it.
+func (b *bleveSearch) Reindex(ctx context.Context) (int, error) {
+	err := b.index.Close()
+	if err != nil {
+		code = "[SEARCH] BleveReindex - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	err = os.RemoveAll(b.path)
+	if err != nil {
+		code = "[SEARCH] BleveReindex - 2"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	b.index, err = bleve.New(b.path, contentIndexMapping())
+	if err != nil {
+		code = "[SEARCH] BleveReindex - 3"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	total, err := b.indexContents(ctx, entity.QueryString{})
+	if err != nil {
+		code = "[SEARCH] BleveReindex - 4"
+		log.Errorw(code, err)
+		return total, err
+	}
+
+	return total, nil
+}
+
+func (b *bleveSearch) IndexContents(ctx context.Context, query entity.QueryString) {
+	_, err := b.indexContents(ctx, query)
+	if err != nil {
+		code = "[SEARCH] BleveIndexContents - 1"
+		log.Errorw(code, err)
+	}
+}
+
+// indexContents loads the contents matching filter in batches and indexes
+// them, it returns how many were indexed.
+func (b *bleveSearch) indexContents(ctx context.Context, filter entity.QueryString) (int, error) {
+	filter.Limit = reindexBatchSize
+	filter.Sort = []sorting.Key{{Name: entity.ContentSortID}}
+
+	paths := b.categoryPaths(ctx)
+
+	total := 0
+	for page := 1; ; page++ {
+		filter.Page = page
+		results, _, _, err := b.contentRepo.GetContents(ctx, filter)
+		if err != nil {
+			return total, err
+		}
+
+		batch := b.index.NewBatch()
+		for _, content := range results {
+			err = batch.Index(strconv.FormatInt(content.ID, 10), toContentDocument(content, paths[content.CategoryID]))
+			if err != nil {
+				return total, err
+			}
+		}
+
+		err = b.index.Batch(batch)
+		if err != nil {
+			return total, err
+		}
+
+		total += len(results)
+		if len(results) < reindexBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (b *bleveSearch) Close() error {
+	return b.index.Close()
+}
+
+// categoryPaths returns the slug of every category followed by the slugs of
+// its ancestors, keyed by category ID. When the categories cannot be loaded
+// documents only get their own category.
+func (b *bleveSearch) categoryPaths(ctx context.Context) map[int64][]string {
+	paths := map[int64][]string{}
+	categories, err := b.categoryRepo.GetCategories(ctx)
+	if err != nil {
+		code = "[SEARCH] BleveCategoryPaths - 1"
+		log.Errorw(code, err)
+		return paths
+	}
+
+	byID := map[int64]entity.CategoryEntity{}
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	for _, category := range categories {
+		path := []string{category.Slug}
+		// the depth limit guards against a cycle in broken data
+		for parentID := category.ParentID; parentID != nil && len(path) <= len(categories); {
+			parent, ok := byID[*parentID]
+			if !ok {
+				break
+			}
+			path = append(path, parent.Slug)
+			parentID = parent.ParentID
+		}
+		paths[category.ID] = path
+	}
+
+	return paths
+}
+
+// contentIndexMapping indexes the searchable text with the standard analyzer
+// and the filter fields as keywords. Fields only needed to render a hit are
+// stored without being indexed.
+func contentIndexMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = standard.Name
+
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+	keywordField.IncludeTermVectors = false
+
+	stored := bleve.NewTextFieldMapping()
+	stored.Index = false
+	stored.IncludeTermVectors = false
+	stored.IncludeInAll = false
+
+	storedNumber := bleve.NewNumericFieldMapping()
+	storedNumber.Index = false
+	storedNumber.IncludeInAll = false
+
+	date := bleve.NewDateTimeFieldMapping()
+
+	content := bleve.NewDocumentStaticMapping()
+	content.AddFieldMappingsAt("title", text)
+	content.AddFieldMappingsAt("excerpt", text)
+	content.AddFieldMappingsAt("description", text)
+	content.AddFieldMappingsAt("status", keywordField)
+	content.AddFieldMappingsAt("category", keywordField)
+	content.AddFieldMappingsAt("category_path", keywordField)
+	content.AddFieldMappingsAt("tag", keywordField)
+	content.AddFieldMappingsAt("author", keywordField)
+	content.AddFieldMappingsAt("date", date)
+	content.AddFieldMappingsAt("created_at", date)
+	content.AddFieldMappingsAt("slug", stored)
+	content.AddFieldMappingsAt("image", stored)
+	content.AddFieldMappingsAt("is_valid", stored)
+	content.AddFieldMappingsAt("category_title", stored)
+	content.AddFieldMappingsAt("tag_name", stored)
+	content.AddFieldMappingsAt("author_name", stored)
+	content.AddFieldMappingsAt("category_id", storedNumber)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = content
+
+	return indexMapping
+}
+
+// toContentDocument flattens a content into the fields of contentIndexMapping.
+// tag and tag_name hold the slugs and names of the tags in the same order,
+// categoryPath the slugs of the category and its ancestors.
+func toContentDocument(content entity.ContentEntity, categoryPath []string) map[string]interface{} {
+	if len(categoryPath) == 0 {
+		categoryPath = []string{content.Category.Slug}
+	}
+
+	tagSlugs := []string{}
+	tagNames := []string{}
+	for _, tag := range content.Tags {
+		tagSlugs = append(tagSlugs, tag.Slug)
+		tagNames = append(tagNames, tag.Name)
+	}
+
+	date := content.CreatedAt
+	if content.PublishAt != nil {
+		date = *content.PublishAt
+	}
+
+	return map[string]interface{}{
+		"title":          content.Title,
+		"excerpt":        content.Excerpt,
+		"description":    plainText(content.Description),
+		"status":         content.Status,
+		"category":       content.Category.Slug,
+		"category_path":  categoryPath,
+		"tag":            tagSlugs,
+		"author":         strconv.FormatInt(content.CreatedByID, 10),
+		"date":           date,
+		"created_at":     content.CreatedAt,
+		"slug":           content.Slug,
+		"image":          content.Image,
+		"is_valid":       content.IsValid,
+		"category_title": content.Category.Title,
+		"tag_name":       tagNames,
+		"author_name":    content.User.Name,
+		"category_id":    float64(content.CategoryID),
+	}
+}
+
+func toSearchContentEntity(hit *search.DocumentMatch, highlight bool) entity.ContentEntity {
+	id, _ := strconv.ParseInt(hit.ID, 10, 64)
+	authorID, _ := strconv.ParseInt(storedString(hit.Fields["author"]), 10, 64)
+	categoryID, _ := hit.Fields["category_id"].(float64)
+
+	content := entity.ContentEntity{
+		ID:          id,
+		Title:       storedString(hit.Fields["title"]),
+		Slug:        storedString(hit.Fields["slug"]),
+		Excerpt:     storedString(hit.Fields["excerpt"]),
+		Image:       storedString(hit.Fields["image"]),
+		Status:      storedString(hit.Fields["status"]),
+		IsValid:     storedString(hit.Fields["is_valid"]),
+		CategoryID:  int64(categoryID),
+		CreatedByID: authorID,
+		Category: entity.CategoryEntity{
+			ID:    int64(categoryID),
+			Title: storedString(hit.Fields["category_title"]),
+			Slug:  storedString(hit.Fields["category"]),
+		},
+		User: entity.UserEntity{
+			ID:   authorID,
+			Name: storedString(hit.Fields["author_name"]),
+		},
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, storedString(hit.Fields["created_at"]))
+	if err == nil {
+		content.CreatedAt = createdAt
+	}
+
+	date, err := time.Parse(time.RFC3339, storedString(hit.Fields["date"]))
+	if err == nil && !date.Equal(content.CreatedAt) {
+		content.PublishAt = &date
+	}
+
+	tagSlugs := storedStrings(hit.Fields["tag"])
+	tagNames := storedStrings(hit.Fields["tag_name"])
+	content.Tags = []entity.TagEntity{}
+	for i, tagSlug := range tagSlugs {
+		tag := entity.TagEntity{Slug: tagSlug}
+		if i < len(tagNames) {
+			tag.Name = tagNames[i]
+		}
+		content.Tags = append(content.Tags, tag)
+	}
+
+	if !highlight {
+		return content
+	}
+
+	// the title is returned in full even when only the description matched
+	content.Highlight = &entity.ContentHighlightEntity{
+		Title:   html.EscapeString(content.Title),
+		Snippet: strings.Join(hit.Fragments["description"], " ... "),
+	}
+	if fragments := hit.Fragments["title"]; len(fragments) > 0 {
+		content.Highlight.Title = fragments[0]
+	}
+
+	return content
+}
+
+// storedString and storedStrings read stored fields, bleve returns a single
+// value as is and several values as a slice.
+func storedString(value interface{}) string {
+	values := storedStrings(value)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func storedStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+// plainText strips the markup of a description before it is indexed.
+func plainText(description string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(markupPattern.ReplaceAllString(description, " "))), " ")
+}
+
+// NewBleveSearch opens the index at SEARCH_INDEX_PATH, an empty one is created
+// when there is none yet. Opening fails after a second when another process
+// holds the index.
+func NewBleveSearch(cfg *config.Config, contentRepo repository.ContentRepository, categoryRepo repository.CategoryRepository) (service.SearchService, error) {
+	index, err := bleve.OpenUsing(cfg.Search.IndexPath, map[string]interface{}{"bolt_timeout": "1s"})
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(cfg.Search.IndexPath, contentIndexMapping())
+	}
+	if err != nil {
+		code = "[SEARCH] NewBleveSearch - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &bleveSearch{
+		index:        index,
+		path:         cfg.Search.IndexPath,
+		fuzziness:    cfg.Search.Fuzziness,
+		facetSize:    cfg.Search.FacetSize,
+		contentRepo:  contentRepo,
+		categoryRepo: categoryRepo,
+	}, nil
+}
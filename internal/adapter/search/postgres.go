@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/internal/core/service"
+	"trustnews/lib/sorting"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// postgresSearch runs searches against the search_vector column of contents.
+// The column is kept up to date by a trigger, so there is nothing to index and
+// no facets are returned.
+type postgresSearch struct {
+	contentRepo  repository.ContentRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+}
+
+func (p *postgresSearch) Search(ctx context.Context, query entity.SearchQueryEntity) (*entity.SearchResultEntity, error) {
+	filter := entity.QueryString{
+		Limit:    query.Limit,
+		Page:     query.Page,
+		Search:   query.Query,
+		Status:   query.Status,
+		AuthorID: query.AuthorID,
+		From:     query.From,
+		To:       query.To,
+		Sort:     []sorting.Key{{Name: entity.ContentSortRelevance, Desc: true}},
+	}
+
+	switch query.OrderBy {
+	case entity.SearchOrderNewest:
+		filter.Sort = []sorting.Key{{Name: entity.ContentSortPublishedAt, Desc: true}}
+	case entity.SearchOrderOldest:
+		filter.Sort = []sorting.Key{{Name: entity.ContentSortPublishedAt}}
+	}
+
+	// an unknown slug matches nothing rather than everything
+	if query.CategorySlug != "" {
+		category, err := p.categoryRepo.GetCategoryBySlug(ctx, query.CategorySlug)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.SearchResultEntity{Contents: []entity.ContentEntity{}}, nil
+		}
+		if err != nil {
+			code = "[SEARCH] PostgresSearch - 1"
+			log.Errorw(code, err)
+			return nil, err
+		}
+		filter.CategoryID = category.ID
+	}
+
+	if query.TagSlug != "" {
+		tag, err := p.tagRepo.GetTagBySlug(ctx, query.TagSlug)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &entity.SearchResultEntity{Contents: []entity.ContentEntity{}}, nil
+		}
+		if err != nil {
+			code = "[SEARCH] PostgresSearch - 2"
+			log.Errorw(code, err)
+			return nil, err
+		}
+		filter.TagID = tag.ID
+	}
+
+	results, totalData, totalPages, err := p.contentRepo.GetContents(ctx, filter)
+	if err != nil {
+		code = "[SEARCH] PostgresSearch - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.SearchResultEntity{
+		Contents:   results,
+		TotalData:  totalData,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (p *postgresSearch) IndexContent(ctx context.Context, content entity.ContentEntity) {}
+
+func (p *postgresSearch) RemoveContent(ctx context.Context, id int64) {}
+
+func (p *postgresSearch) IndexContents(ctx context.Context, query entity.QueryString) {}
+
+func (p *postgresSearch) Reindex(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (p *postgresSearch) Close() error {
+	return nil
+}
+
+func NewPostgresSearch(contentRepo repository.ContentRepository, categoryRepo repository.CategoryRepository, tagRepo repository.TagRepository) service.SearchService {
+	return &postgresSearch{
+		contentRepo:  contentRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+	}
+}
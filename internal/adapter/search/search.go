@@ -0,0 +1,28 @@
+package search
+
+import (
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/service"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+var code string
+
+// NewSearchService picks the implementation from SEARCH_DRIVER. Anything other
+// than "bleve" uses Postgres, so does a bleve index that cannot be opened.
+func NewSearchService(cfg *config.Config, contentRepo repository.ContentRepository, categoryRepo repository.CategoryRepository, tagRepo repository.TagRepository) service.SearchService {
+	if cfg.Search.Driver == "bleve" {
+		bleveSearch, err := NewBleveSearch(cfg, contentRepo, categoryRepo)
+		if err == nil {
+			return bleveSearch
+		}
+
+		code = "[SEARCH] NewSearchService - 1"
+		log.Errorw(code, err)
+		log.Warn("search index unavailable, falling back to postgres search")
+	}
+
+	return NewPostgresSearch(contentRepo, categoryRepo, tagRepo)
+}
@@ -4,11 +4,15 @@ import (
 	"trustnews/config"
 	"trustnews/internal/adapter/cloudflare"
 	"trustnews/internal/adapter/handler"
+	"trustnews/internal/adapter/mailer"
 	"trustnews/internal/adapter/repository"
+	"trustnews/internal/adapter/search"
+	"trustnews/internal/core/domain/entity"
 	"trustnews/internal/core/service"
 	"trustnews/lib/auth"
 	"trustnews/lib/middleware"
 	"trustnews/lib/pagination"
+	"trustnews/lib/slug"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gofiber/contrib/swagger"
@@ -29,13 +33,13 @@ func RunServer() {
 	cfg := config.NewConfig()
 	db, err := cfg.ConnectionPostgres()
 	if err != nil {
-		log.Fatal("Error connecting to database: %v", err)
+		log.Fatalf("Error connecting to database: %v", err)
 		return
 	}
 
 	err = os.MkdirAll("./temp/content", 0755)
 	if err != nil {
-		log.Fatal("Error Creating Temp Directory: %v", err)
+		log.Fatalf("Error Creating Temp Directory: %v", err)
 		return
 	}
 
@@ -45,7 +49,7 @@ func RunServer() {
 	r2Adapter := cloudflare.NewCloudFlareR2Adapter(s3Client, cfg)
 
 	jwt := auth.NewJwt(cfg)
-	middlewareAuth := middleware.NewMiddleware(cfg)
+	mailSender := mailer.NewMailer(cfg)
 
 	_ = pagination.NewPagination()
 
@@ -54,22 +58,58 @@ func RunServer() {
 	categoryRepo := repository.NewCategoryRepository(db.DB)
 	contentRepo := repository.NewContentRepository(db.DB)
 	userRepo := repository.NewUserRepository(db.DB)
+	twoFactorRepo := repository.NewTwoFactorRepository(db.DB)
+	loginThrottleRepo := repository.NewLoginThrottleRepository(db.DB)
+	apiKeyRepo := repository.NewApiKeyRepository(db.DB)
+	auditLogRepo := repository.NewAuditLogRepository(db.DB)
+	factCheckRepo := repository.NewFactCheckRepository(db.DB)
+	correctionRepo := repository.NewContentCorrectionRepository(db.DB)
+	reportRepo := repository.NewContentReportRepository(db.DB)
+	tagRepo := repository.NewTagRepository(db.DB)
+	suggestionRepo := repository.NewSuggestionRepository(db.DB)
+
+	middlewareAuth := middleware.NewMiddleware(jwt, authRepo, apiKeyRepo)
+
+	slugifier := slug.New(cfg.App.SlugMaxLength, cfg.App.SlugStopWords)
+	searchService := search.NewSearchService(cfg, contentRepo, categoryRepo, tagRepo)
 
 	// Service
-	authService := service.NewAuthService(authRepo, cfg, jwt)
-	categoryService := service.NewCategoryService(categoryRepo)
-	contentService := service.NewContentService(contentRepo, cfg, r2Adapter)
-	userService := service.NewUserService(userRepo)
+	auditService := service.NewAuditService(auditLogRepo)
+	authService := service.NewAuthService(authRepo, twoFactorRepo, loginThrottleRepo, cfg, jwt, mailSender)
+	categoryService := service.NewCategoryService(categoryRepo, auditService, slugifier, searchService)
+	contentService := service.NewContentService(contentRepo, cfg, r2Adapter, auditService, slugifier, searchService)
+	userService := service.NewUserService(userRepo, auditService, searchService)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userRepo, cfg)
+	lockoutService := service.NewLockoutService(loginThrottleRepo)
+	apiKeyService := service.NewApiKeyService(apiKeyRepo, userRepo)
+	contentSchedulerService := service.NewContentSchedulerService(contentRepo, auditService, searchService, cfg)
+	factCheckService := service.NewFactCheckService(factCheckRepo, contentRepo, auditService, searchService, cfg)
+	correctionService := service.NewCorrectionService(correctionRepo, contentRepo, auditService)
+	reportService := service.NewReportService(reportRepo, contentRepo, auditService, searchService)
+	tagService := service.NewTagService(tagRepo, contentRepo, auditService, slugifier, searchService)
+	suggestionService := service.NewSuggestionService(suggestionRepo)
 
 	// Handler
 	authHandler := handler.NewAuthHandler(authService)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
-	contentHandler := handler.NewContentHandler(contentService)
+	contentHandler := handler.NewContentHandler(contentService, categoryService, tagService, []byte(cfg.App.CursorSecretKey))
 	userHandler := handler.NewUserHandler(userService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	lockoutHandler := handler.NewLockoutHandler(lockoutService)
+	apiKeyHandler := handler.NewApiKeyHandler(apiKeyService)
+	auditLogHandler := handler.NewAuditLogHandler(auditService)
+	factCheckHandler := handler.NewFactCheckHandler(factCheckService)
+	correctionHandler := handler.NewCorrectionHandler(correctionService)
+	reportHandler := handler.NewReportHandler(reportService)
+	tagHandler := handler.NewTagHandler(tagService)
+	searchHandler := handler.NewSearchHandler(searchService, suggestionService)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ProxyHeader: cfg.App.ProxyHeader,
+	})
 	app.Use(cors.New())
 	app.Use(recover.New())
+	app.Use(middleware.RequestMeta())
 	app.Use(logger.New(logger.Config{
 		Format: "[${time}] %{ip} %{status} - %{latency} %{method} %{path}\n",
 	}))
@@ -85,39 +125,131 @@ func RunServer() {
 		app.Use(swagger.New(cfg))
 	}
 
+	app.Get("/.well-known/jwks.json", authHandler.Jwks)
+
 	api := app.Group("/api")
 	api.Post("/login", authHandler.Login)
+	api.Post("/login/2fa", authHandler.LoginTwoFactor)
+	api.Post("/refresh", authHandler.RefreshToken)
+	api.Post("/logout", middlewareAuth.CheckSetupToken(), authHandler.Logout)
+	// forgot and reset share one limit, so neither can be used to get around it
+	passwordRateLimit := middleware.RateLimit(cfg.App.PasswordRateLimit, cfg.App.PasswordRateWindow)
+	api.Post("/password/forgot", passwordRateLimit, authHandler.ForgotPassword)
+	api.Post("/password/reset", passwordRateLimit, authHandler.ResetPassword)
 
+	// Two-factor enrollment is reachable with a setup-only token as well
+	twoFactorApp := api.Group("/2fa", middlewareAuth.CheckSetupToken())
+	twoFactorApp.Post("/enroll", twoFactorHandler.Enroll)
+	twoFactorApp.Post("/verify", twoFactorHandler.Verify)
+	twoFactorApp.Post("/disable", twoFactorHandler.Disable)
+
+	// Admin routes accept user tokens and API keys, every route has to declare
+	// the roles (and for API keys the scope) it is open to.
 	adminApp := api.Group("/admin")
 	adminApp.Use(middlewareAuth.CheckToken())
 
+	staffRoles := []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleAuthor, entity.RoleFactChecker}
+	editorRoles := []string{entity.RoleAdmin, entity.RoleEditor}
+	writerRoles := []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleAuthor}
+	reviewerRoles := []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleFactChecker}
+
 	// Category
 	categoryApp := adminApp.Group("/categories")
-	categoryApp.Get("/", categoryHandler.GetCategories)
-	categoryApp.Post("/", categoryHandler.CreateCategory)
-	categoryApp.Put("/:categoryID", categoryHandler.EditCategoryByID)
-	categoryApp.Get("/:categoryID", categoryHandler.GetCategoryByID)
-	categoryApp.Delete("/:categoryID", categoryHandler.DeleteCategory)
+	categoryApp.Get("/", middlewareAuth.RequireAccess(entity.ScopeCategoriesRead), categoryHandler.GetCategories)
+	categoryApp.Post("/", middlewareAuth.RequireAccess(entity.ScopeCategoriesWrite, editorRoles...), categoryHandler.CreateCategory)
+	categoryApp.Put("/:categoryID", middlewareAuth.RequireAccess(entity.ScopeCategoriesWrite, editorRoles...), categoryHandler.EditCategoryByID)
+	categoryApp.Get("/:categoryID", middlewareAuth.RequireAccess(entity.ScopeCategoriesRead), categoryHandler.GetCategoryByID)
+	categoryApp.Delete("/:categoryID", middlewareAuth.RequireAccess(entity.ScopeCategoriesWrite, editorRoles...), categoryHandler.DeleteCategory)
 
 	// Content
 	contentApp := adminApp.Group("/contents")
-	contentApp.Get("/", contentHandler.GetContents)
-	contentApp.Post("/", contentHandler.CreateContent)
-	contentApp.Put("/:contentID", contentHandler.UpdateContent)
-	contentApp.Get("/:contentID", contentHandler.GetContentByID)
-	contentApp.Delete("/:contentID", contentHandler.DeleteContent)
-	contentApp.Post("/upload-image", contentHandler.UploadImageR2)
+	contentApp.Get("/", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetContents)
+	contentApp.Post("/", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, writerRoles...), contentHandler.CreateContent)
+	contentApp.Put("/:contentID", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, writerRoles...), contentHandler.UpdateContent)
+	contentApp.Get("/:contentID", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetContentByID)
+	contentApp.Delete("/:contentID", middlewareAuth.RequireAccess(entity.ScopeContentsDelete, editorRoles...), contentHandler.DeleteContent)
+	contentApp.Post("/upload-image", middlewareAuth.RequireAccess(entity.ScopeMediaUpload, writerRoles...), contentHandler.UploadImageR2)
+	contentApp.Get("/:contentID/revisions", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetRevisions)
+	contentApp.Get("/:contentID/revisions/diff", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetRevisionDiff)
+	contentApp.Get("/:contentID/revisions/:revisionNumber", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetRevision)
+	contentApp.Post("/:contentID/revisions/:revisionNumber/restore", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, writerRoles...), contentHandler.RestoreRevision)
+	contentApp.Get("/:contentID/fact-check", middlewareAuth.RequireAccess(entity.ScopeContentsRead), factCheckHandler.GetFactCheck)
+	contentApp.Put("/:contentID/fact-check", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, reviewerRoles...), factCheckHandler.SaveFactCheck)
+	contentApp.Delete("/:contentID/fact-check", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, reviewerRoles...), factCheckHandler.DeleteFactCheck)
+	contentApp.Get("/:contentID/corrections", middlewareAuth.RequireAccess(entity.ScopeContentsRead), correctionHandler.GetCorrections)
+	contentApp.Post("/:contentID/corrections", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), correctionHandler.CreateCorrection)
+	contentApp.Delete("/:contentID/corrections/:correctionID", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), correctionHandler.DeleteCorrection)
+	contentApp.Get("/:contentID/reviews", middlewareAuth.RequireAccess(entity.ScopeContentsRead), contentHandler.GetContentReviews)
+	contentApp.Post("/:contentID/submit", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, writerRoles...), contentHandler.SubmitContent)
+	contentApp.Post("/:contentID/approve", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, reviewerRoles...), contentHandler.ApproveContent)
+	contentApp.Post("/:contentID/reject", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, reviewerRoles...), contentHandler.RejectContent)
+	contentApp.Post("/:contentID/schedule", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), contentHandler.ScheduleContent)
+	contentApp.Post("/:contentID/publish", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), contentHandler.PublishContent)
+	contentApp.Post("/:contentID/archive", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), contentHandler.ArchiveContent)
+
+	// Tag
+	tagApp := adminApp.Group("/tags")
+	tagApp.Get("/", middlewareAuth.RequireAccess(entity.ScopeContentsRead), tagHandler.GetTags)
+	tagApp.Put("/:tagID", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), tagHandler.RenameTag)
+	tagApp.Post("/:tagID/merge", middlewareAuth.RequireAccess(entity.ScopeContentsWrite, editorRoles...), tagHandler.MergeTags)
+	tagApp.Delete("/:tagID", middlewareAuth.RequireAccess(entity.ScopeContentsDelete, editorRoles...), tagHandler.DeleteTag)
+
+	// Report
+	reportApp := adminApp.Group("/reports", middlewareAuth.RequireRole(reviewerRoles...))
+	reportApp.Get("/", reportHandler.GetReports)
+	reportApp.Get("/:reportID", reportHandler.GetReportByID)
+	reportApp.Put("/:reportID/status", reportHandler.ModerateReport)
 
 	// User
 	userApp := adminApp.Group("/users")
-	userApp.Get("/profile", userHandler.GetUserByID)
-	userApp.Put("/update-password", userHandler.UpdatePassword)
+	userApp.Get("/profile", middlewareAuth.RequireRole(staffRoles...), userHandler.GetUserByID)
+	userApp.Put("/update-password", middlewareAuth.RequireRole(staffRoles...), userHandler.UpdatePassword)
+
+	adminRoles := middlewareAuth.RequireRole(entity.RoleAdmin)
+	userApp.Get("/", adminRoles, userHandler.GetUsers)
+	userApp.Post("/", adminRoles, userHandler.CreateUser)
+	userApp.Get("/:userID", adminRoles, userHandler.GetUserDetail)
+	userApp.Put("/:userID", adminRoles, userHandler.UpdateUser)
+	userApp.Post("/:userID/deactivate", adminRoles, userHandler.DeactivateUser)
+	userApp.Post("/:userID/reactivate", adminRoles, userHandler.ReactivateUser)
+
+	// Security
+	securityApp := adminApp.Group("/security", adminRoles)
+	securityApp.Get("/two-factor-roles", twoFactorHandler.GetRequiredRoles)
+	securityApp.Put("/two-factor-roles", twoFactorHandler.SetRequiredRoles)
+	securityApp.Get("/lockouts", lockoutHandler.GetLockoutEvents)
+
+	// API keys
+	apiKeyApp := adminApp.Group("/api-keys", adminRoles)
+	apiKeyApp.Get("/", apiKeyHandler.GetApiKeys)
+	apiKeyApp.Post("/", apiKeyHandler.CreateApiKey)
+	apiKeyApp.Delete("/:apiKeyID", apiKeyHandler.RevokeApiKey)
+
+	// Audit
+	adminApp.Get("/audit-logs", adminRoles, auditLogHandler.GetAuditLogs)
 
 	// FE
 	feApp := api.Group("/fe")
 	feApp.Get("/categories", categoryHandler.GetCategoryFE)
+	feApp.Get("/categories/:slug/contents", contentHandler.GetContentsByCategorySlug)
+	feApp.Get("/tags/:slug/contents", contentHandler.GetContentsByTagSlug)
 	feApp.Get("/contents", contentHandler.GetContentWithQuery)
+	feApp.Get("/contents/slug/:slug", contentHandler.GetContentBySlug)
 	feApp.Get("/contents/:contentID", contentHandler.GetContentDetail)
+	feApp.Get("/contents/:contentID/claim-review", factCheckHandler.GetClaimReview)
+	feApp.Post("/contents/:contentID/reports", middleware.RateLimit(cfg.App.ReportRateLimit, cfg.App.ReportRateWindow), reportHandler.CreateReport)
+	feApp.Get("/corrections", correctionHandler.GetPublishedCorrections)
+	feApp.Get("/search", searchHandler.Search)
+	feApp.Get("/search/suggest", middleware.Cache(cfg.Search.SuggestCacheTTL), searchHandler.Suggest)
+
+	// tags migrated from the old column have no tag page until they are slugged
+	err = tagService.SlugLegacyTags(context.Background())
+	if err != nil {
+		log.Printf("Error slugging legacy tags: %v", err)
+	}
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go contentSchedulerService.Start(schedulerCtx)
 
 	go func() {
 		if cfg.App.AppPort == "" {
@@ -126,7 +258,7 @@ func RunServer() {
 
 		err := app.Listen(":" + cfg.App.AppPort)
 		if err != nil {
-			log.Fatal("Error starting server: %v", err)
+			log.Fatalf("Error starting server: %v", err)
 		}
 	}()
 
@@ -135,10 +267,15 @@ func RunServer() {
 	signal.Notify(quit, syscall.SIGTERM)
 
 	<-quit
+	stopScheduler()
 
 	log.Println("server shutdown of 5 seconds")
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	app.ShutdownWithContext(ctx)
+
+	if err := searchService.Close(); err != nil {
+		log.Printf("Error closing search index: %v", err)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+const (
+	ScopeContentsRead    = "contents:read"
+	ScopeContentsWrite   = "contents:write"
+	ScopeContentsDelete  = "contents:delete"
+	ScopeCategoriesRead  = "categories:read"
+	ScopeCategoriesWrite = "categories:write"
+	ScopeMediaUpload     = "media:upload"
+)
+
+var ApiKeyScopes = []string{
+	ScopeContentsRead,
+	ScopeContentsWrite,
+	ScopeContentsDelete,
+	ScopeCategoriesRead,
+	ScopeCategoriesWrite,
+	ScopeMediaUpload,
+}
+
+// ApiKeyScopeRoles lists the roles that can use each scope, a key only gets
+// scopes its owner's role can use.
+var ApiKeyScopeRoles = map[string][]string{
+	ScopeContentsRead:    {RoleAdmin, RoleEditor, RoleAuthor, RoleFactChecker},
+	ScopeContentsWrite:   {RoleAdmin, RoleEditor, RoleAuthor, RoleFactChecker},
+	ScopeContentsDelete:  {RoleAdmin, RoleEditor},
+	ScopeCategoriesRead:  {RoleAdmin, RoleEditor, RoleAuthor, RoleFactChecker},
+	ScopeCategoriesWrite: {RoleAdmin, RoleEditor},
+	ScopeMediaUpload:     {RoleAdmin, RoleEditor, RoleAuthor},
+}
+
+type ApiKeyEntity struct {
+	ID          int64
+	Name        string
+	KeyPrefix   string
+	KeyHash     string
+	Scopes      []string
+	UserID      int64
+	User        UserEntity
+	CreatedByID int64
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+	"trustnews/lib/diff"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditEntityContent    = "content"
+	AuditEntityCategory   = "category"
+	AuditEntityUser       = "user"
+	AuditEntityFactCheck  = "fact_check"
+	AuditEntityCorrection = "correction"
+	AuditEntityReport     = "report"
+	AuditEntityTag        = "tag"
+)
+
+// RequestMetaKey is the c.Locals key holding the RequestMeta of a request.
+const RequestMetaKey = "request_meta"
+
+type RequestMeta struct {
+	IPAddress string
+	UserAgent string
+}
+
+type AuditLogEntity struct {
+	ID         int64
+	ActorID    *int64
+	Actor      *UserEntity
+	ApiKeyID   *int64
+	Action     string
+	EntityType string
+	EntityID   int64
+	Changes    map[string]diff.Change
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
+
+type AuditLogQuery struct {
+	Limit      int
+	Page       int
+	ActorID    int64
+	Action     string
+	EntityType string
+	EntityID   int64
+	From       *time.Time
+	To         *time.Time
+}
@@ -1,11 +1,40 @@
 package entity
 
+import "time"
+
 type LoginRequest struct {
 	Email string
 	Password string
+	IPAddress string
 }
 
 type AccessToken struct {
 	AccessToken string
 	ExpiresAt int64
-}
\ No newline at end of file
+	RefreshToken string
+	RefreshExpiresAt int64
+	MfaRequired bool
+	MfaToken string
+	MfaExpiresAt int64
+	MfaSetupRequired bool
+}
+
+type RefreshTokenEntity struct {
+	ID int64
+	UserID int64
+	FamilyID string
+	TokenHash string
+	AccessTokenID string
+	AccessExpiresAt time.Time
+	ExpiresAt time.Time
+	UsedAt *time.Time
+	RevokedAt *time.Time
+}
+
+type PasswordResetEntity struct {
+	ID int64
+	UserID int64
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt *time.Time
+}
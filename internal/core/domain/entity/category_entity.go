@@ -4,5 +4,12 @@ type CategoryEntity struct {
 	ID int64
 	Title string
 	Slug string
+	ParentID *int64
+	SortOrder int
+	Description string
+	SeoTitle string
+	SeoDescription string
 	User  UserEntity
+	// Children is only filled when categories are returned as a tree
+	Children []CategoryEntity
 }
\ No newline at end of file
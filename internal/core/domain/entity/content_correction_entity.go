@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	CorrectionSeverityMinor    = "MINOR"
+	CorrectionSeverityMajor    = "MAJOR"
+	CorrectionSeverityCritical = "CRITICAL"
+)
+
+type ContentCorrectionEntity struct {
+	ID           int64
+	ContentID    int64
+	ContentTitle string
+	CorrectedAt  time.Time
+	WhatWasWrong string
+	WhatChanged  string
+	Severity     string
+	CreatedByID  int64
+	CreatedBy    *UserEntity
+	CreatedAt    time.Time
+}
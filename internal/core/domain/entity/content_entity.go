@@ -1,29 +1,101 @@
 package entity
 
-import "time"
+import (
+	"time"
+	"trustnews/lib/sorting"
+)
+
+// Values of the is_valid column. NEEDS_REVIEW is set when a reader report is
+// upheld, the content stays online until an editor has looked at it again.
+const (
+	ContentValidityValid       = "VALID"
+	ContentValidityNeedsReview = "NEEDS_REVIEW"
+	ContentValidityInvalid     = "INVALID"
+)
+
+// Sort keys of content listings, every endpoint declares which of them it
+// allows. -relevance puts the best matches of a search first and falls back
+// to the creation date without one, popularity sorts on the view count.
+const (
+	ContentSortID          = "id"
+	ContentSortTitle       = "title"
+	ContentSortStatus      = "status"
+	ContentSortCreatedAt   = "created_at"
+	ContentSortUpdatedAt   = "updated_at"
+	ContentSortPublishedAt = "published_at"
+	ContentSortPopularity  = "popularity"
+	ContentSortRelevance   = "relevance"
+)
 
 type ContentEntity struct {
 	ID          int64
 	Title       string
+	Slug        string
 	Excerpt     string
 	Description string
 	Image       string
-	Tags        []string
+	Tags        []TagEntity
 	Status      string
 	IsValid     string
 	CategoryID  int64
 	CreatedByID int64
+	EditedByID  int64
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	// KeepPublishAt and KeepUnpublishAt leave the stored schedule untouched on
+	// an update, a nil time on its own clears it
+	KeepPublishAt	bool
+	KeepUnpublishAt	bool
 	CreatedAt   time.Time
+	UpdatedAt   *time.Time
 	Category 	CategoryEntity
 	User 		UserEntity
+	FactCheck	*FactCheckEntity
+	// Sources is nil when an update should leave the sources untouched
+	Sources		[]ContentSourceEntity
+	Corrections	[]ContentCorrectionEntity
+	// Highlight is only set on search results
+	Highlight	*ContentHighlightEntity
+}
+
+// ContentHighlightEntity holds the title and a description snippet with the
+// search matches wrapped in <mark>.
+type ContentHighlightEntity struct {
+	Title   string
+	Snippet string
 }
 
 type QueryString struct {
 	Limit 		int
 	Page 		int
-	OrderBy 	string
-	OrderType	string
+	Sort		[]sorting.Key
 	Search 		string
 	CategoryID	int64
 	Status		string
+	SourceDomain	string
+	TagID		int64
+	AuthorID	int64
+	From		*time.Time
+	To		*time.Time
+	// IDs limits the contents to these when it is not nil
+	IDs		[]int64
+	// Cursor is only read by keyset paging, which ignores Page
+	Cursor		*CursorEntity
+}
+
+// CursorEntity is the position of a keyset page. Values holds the sort values
+// of the row the page starts after, or ends before when Backward is set.
+type CursorEntity struct {
+	Values   []interface{}
+	Backward bool
+}
+
+// ContentPageEntity is one keyset page of contents, Next and Prev are nil at
+// either end of the list.
+type ContentPageEntity struct {
+	Contents   []ContentEntity
+	TotalData  int64
+	TotalPages int64
+	Next       *CursorEntity
+	Prev       *CursorEntity
 }
\ No newline at end of file
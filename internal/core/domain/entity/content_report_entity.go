@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+const (
+	ReportReasonFactualError   = "FACTUAL_ERROR"
+	ReportReasonMisleading     = "MISLEADING"
+	ReportReasonMissingContext = "MISSING_CONTEXT"
+	ReportReasonOutdated       = "OUTDATED"
+	ReportReasonOther          = "OTHER"
+)
+
+const (
+	ReportStatusNew      = "NEW"
+	ReportStatusTriaged  = "TRIAGED"
+	ReportStatusUpheld   = "UPHELD"
+	ReportStatusRejected = "REJECTED"
+)
+
+// ReportStatuses lists the moderation queue states in order.
+var ReportStatuses = []string{
+	ReportStatusNew,
+	ReportStatusTriaged,
+	ReportStatusUpheld,
+	ReportStatusRejected,
+}
+
+type ContentReportEntity struct {
+	ID            int64
+	ContentID     int64
+	ContentTitle  string
+	Reason        string
+	Details       string
+	Email         string
+	IPAddress     string
+	UserAgent     string
+	Status        string
+	ModeratorID   int64
+	Moderator     *UserEntity
+	ModeratorNote string
+	ResolvedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
+
+type ContentReportQuery struct {
+	Limit     int
+	Page      int
+	Status    string
+	ContentID int64
+}
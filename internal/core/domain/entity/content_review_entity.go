@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+const (
+	ContentStatusDraft     = "DRAFT"
+	ContentStatusInReview  = "IN_REVIEW"
+	ContentStatusApproved  = "APPROVED"
+	ContentStatusScheduled = "SCHEDULED"
+	ContentStatusPublished = "PUBLISHED"
+	ContentStatusArchived  = "ARCHIVED"
+)
+
+// ContentStatuses lists every workflow state in order.
+var ContentStatuses = []string{
+	ContentStatusDraft,
+	ContentStatusInReview,
+	ContentStatusApproved,
+	ContentStatusScheduled,
+	ContentStatusPublished,
+	ContentStatusArchived,
+}
+
+const (
+	ContentActionSubmit   = "submit"
+	ContentActionApprove  = "approve"
+	ContentActionReject   = "reject"
+	ContentActionSchedule = "schedule"
+	ContentActionPublish  = "publish"
+	ContentActionArchive  = "archive"
+	// ContentActionEdit is recorded when an edit sends a reviewed content back
+	// to review, it cannot be requested directly
+	ContentActionEdit = "edit"
+)
+
+type ContentReviewEntity struct {
+	ID         int64
+	ContentID  int64
+	Action     string
+	FromStatus string
+	ToStatus   string
+	Comment    string
+	ReviewerID int64
+	Reviewer   *UserEntity
+	CreatedAt  time.Time
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+	"trustnews/lib/diff"
+)
+
+type ContentRevisionEntity struct {
+	ID             int64
+	ContentID      int64
+	RevisionNumber int
+	Title          string
+	Excerpt        string
+	Description    string
+	Image          string
+	Tags           []string
+	// Sources is nil for revisions made before sources were recorded
+	Sources      []ContentSourceEntity
+	Status       string
+	IsValid      string
+	CategoryID   int64
+	EditedBy     *UserEntity
+	RestoredFrom *int
+	CreatedAt    time.Time
+}
+
+type ContentRevisionDiffEntity struct {
+	From    ContentRevisionEntity
+	To      ContentRevisionEntity
+	Changes map[string]diff.Change
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type ContentSourceEntity struct {
+	ID         int64
+	Url        string
+	Domain     string
+	Publisher  string
+	AccessedAt *time.Time
+	ArchiveUrl string
+	Note       string
+}
@@ -0,0 +1,72 @@
+package entity
+
+import "time"
+
+const (
+	RatingTrue       = "TRUE"
+	RatingMostlyTrue = "MOSTLY_TRUE"
+	RatingMisleading = "MISLEADING"
+	RatingFalse      = "FALSE"
+	RatingSatire     = "SATIRE"
+)
+
+// FactCheckRatings lists the rating scale from best to worst, satire sits
+// outside of the scale.
+var FactCheckRatings = []string{
+	RatingTrue,
+	RatingMostlyTrue,
+	RatingMisleading,
+	RatingFalse,
+	RatingSatire,
+}
+
+// FactCheckRatingLabels are the human readable names of the ratings.
+var FactCheckRatingLabels = map[string]string{
+	RatingTrue:       "True",
+	RatingMostlyTrue: "Mostly True",
+	RatingMisleading: "Misleading",
+	RatingFalse:      "False",
+	RatingSatire:     "Satire",
+}
+
+// FactCheckRatingValues places the ratings on a 1 (worst) to 5 (best) scale
+// for structured data, satire has no value on it.
+var FactCheckRatingValues = map[string]int{
+	RatingTrue:       5,
+	RatingMostlyTrue: 4,
+	RatingMisleading: 2,
+	RatingFalse:      1,
+}
+
+// FactCheckRatingValidity is the is_valid a content gets from its fact check
+// verdict. Satire is labelled by its rating and not marked invalid.
+var FactCheckRatingValidity = map[string]string{
+	RatingTrue:       ContentValidityValid,
+	RatingMostlyTrue: ContentValidityValid,
+	RatingMisleading: ContentValidityInvalid,
+	RatingFalse:      ContentValidityInvalid,
+	RatingSatire:     ContentValidityValid,
+}
+
+type FactCheckEntity struct {
+	ID            int64
+	ContentID     int64
+	Claim         string
+	Claimant      string
+	Rating        string
+	ReviewerID    int64
+	Reviewer      *UserEntity
+	ReviewedAt    time.Time
+	EvidenceLinks []string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
+
+// ClaimReviewEntity holds what is needed to render a schema.org ClaimReview.
+type ClaimReviewEntity struct {
+	Url       string
+	SiteName  string
+	SiteUrl   string
+	Title     string
+	FactCheck FactCheckEntity
+}
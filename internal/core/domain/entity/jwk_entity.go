@@ -0,0 +1,12 @@
+package entity
+
+type JwkEntity struct {
+	Kty string
+	Use string
+	Alg string
+	Kid string
+	N   string
+	E   string
+	Crv string
+	X   string
+}
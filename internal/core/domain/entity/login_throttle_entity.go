@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+const (
+	ThrottleScopeIP      = "ip"
+	ThrottleScopeAccount = "account"
+)
+
+type LockoutEventEntity struct {
+	ID          int64
+	Scope       string
+	Identifier  string
+	UserID      *int64
+	IPAddress   string
+	Failures    int
+	LockedUntil time.Time
+	CreatedAt   time.Time
+}
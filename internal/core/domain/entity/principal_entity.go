@@ -0,0 +1,28 @@
+package entity
+
+import "slices"
+
+const (
+	PrincipalUser   = "user"
+	PrincipalApiKey = "api_key"
+)
+
+// Principal is what the auth middleware stores in c.Locals("user"), for a
+// logged in user as well as for a machine client using an API key. API keys
+// act on behalf of the user that owns them.
+type Principal struct {
+	Kind     string
+	UserID   int64
+	Role     string
+	ApiKeyID int64
+	Scopes   []string
+	Claims   *JwtData
+}
+
+func (p *Principal) IsApiKey() bool {
+	return p.Kind == PrincipalApiKey
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
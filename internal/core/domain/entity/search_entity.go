@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// Facets returned by a search, terms are category slugs, tag slugs and author
+// ids. The same values are accepted as filters.
+const (
+	SearchFacetCategory = "category"
+	SearchFacetTag      = "tag"
+	SearchFacetAuthor   = "author"
+)
+
+// Orders supported by a search, relevance is the default.
+const (
+	SearchOrderRelevance = "relevance"
+	SearchOrderNewest    = "newest"
+	SearchOrderOldest    = "oldest"
+)
+
+type SearchQueryEntity struct {
+	Query        string
+	CategorySlug string
+	TagSlug      string
+	AuthorID     int64
+	Status       string
+	// From and To filter on the publish date, or the creation date of
+	// contents that have none
+	From    *time.Time
+	To      *time.Time
+	OrderBy string
+	Page    int
+	Limit   int
+}
+
+type SearchFacetEntity struct {
+	Term  string
+	Count int
+}
+
+type SearchResultEntity struct {
+	Contents   []ContentEntity
+	TotalData  int64
+	TotalPages int64
+	// Facets is keyed by SearchFacetCategory, SearchFacetTag and
+	// SearchFacetAuthor, it is nil when the backend has no facets
+	Facets map[string][]SearchFacetEntity
+}
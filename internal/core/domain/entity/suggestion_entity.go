@@ -0,0 +1,9 @@
+package entity
+
+// SuggestionEntity holds what the search box offers while a reader types,
+// only ids, titles and slugs are filled in.
+type SuggestionEntity struct {
+	Contents   []ContentEntity
+	Categories []CategoryEntity
+	Tags       []TagEntity
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type TagEntity struct {
+	ID         int64
+	Name       string
+	Slug       string
+	UsageCount int
+	CreatedAt  time.Time
+}
+
+// TagNames returns the names of tags in order.
+func TagNames(tags []TagEntity) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type TwoFactorEntity struct {
+	UserID   int64
+	Secret   string
+	Enabled  bool
+	LastStep int64
+}
+
+type TwoFactorEnrollment struct {
+	Secret        string
+	OtpauthUri    string
+	RecoveryCodes []string
+}
+
+type MfaChallengeEntity struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
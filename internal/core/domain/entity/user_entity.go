@@ -1,8 +1,23 @@
 package entity
 
+import "time"
+
+const (
+	RoleAdmin       = "admin"
+	RoleEditor      = "editor"
+	RoleAuthor      = "author"
+	RoleFactChecker = "fact_checker"
+)
+
 type UserEntity struct {
 	ID int64
 	Name string
 	Email string
 	Password string
+	Role string
+	IsActive bool
+	TwoFactorEnabled bool
+	// SessionsRevokedAt refuses the access tokens issued before it
+	SessionsRevokedAt *time.Time
+	CreatedAt time.Time
 }
\ No newline at end of file
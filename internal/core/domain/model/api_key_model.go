@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type ApiKey struct {
+	ID          int64      `gorm:"id"`
+	Name        string     `gorm:"name"`
+	KeyPrefix   string     `gorm:"key_prefix"`
+	KeyHash     string     `gorm:"key_hash"`
+	Scopes      string     `gorm:"scopes"`
+	UserID      int64      `gorm:"user_id"`
+	User        User       `gorm:"foreignKey:UserID"`
+	CreatedByID int64      `gorm:"created_by_id"`
+	ExpiresAt   *time.Time `gorm:"expires_at"`
+	LastUsedAt  *time.Time `gorm:"last_used_at"`
+	RevokedAt   *time.Time `gorm:"revoked_at"`
+	CreatedAt   time.Time  `gorm:"created_at"`
+}
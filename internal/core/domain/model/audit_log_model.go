@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type AuditLog struct {
+	ID         int64     `gorm:"id"`
+	ActorID    *int64    `gorm:"actor_id"`
+	Actor      *User     `gorm:"foreignKey:ActorID"`
+	ApiKeyID   *int64    `gorm:"api_key_id"`
+	Action     string    `gorm:"action"`
+	EntityType string    `gorm:"entity_type"`
+	EntityID   int64     `gorm:"entity_id"`
+	Changes    string    `gorm:"changes"`
+	IPAddress  string    `gorm:"ip_address"`
+	UserAgent  string    `gorm:"user_agent"`
+	CreatedAt  time.Time `gorm:"created_at"`
+}
@@ -3,11 +3,16 @@ package model
 import "time"
 
 type Category struct {
-	ID          int64      `gorm:"id"`
-	Title       string     `gorm:"title"`
-	Slug        string     `gorm:"slug"`
-	CreatedByID int64      `gorm:"created_by_id"`
-	User        User       `gorm:"foreignKey:CreatedByID"`
-	CreatedAt   time.Time  `gorm:"created_at"`
-	UpdatedAt   *time.Time `gorm:"updated_at"`
+	ID             int64      `gorm:"id"`
+	Title          string     `gorm:"title"`
+	Slug           string     `gorm:"slug"`
+	ParentID       *int64     `gorm:"parent_id"`
+	SortOrder      int        `gorm:"sort_order"`
+	Description    string     `gorm:"description"`
+	SeoTitle       string     `gorm:"seo_title"`
+	SeoDescription string     `gorm:"seo_description"`
+	CreatedByID    int64      `gorm:"created_by_id"`
+	User           User       `gorm:"foreignKey:CreatedByID"`
+	CreatedAt      time.Time  `gorm:"created_at"`
+	UpdatedAt      *time.Time `gorm:"updated_at"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type ContentCorrection struct {
+	ID           int64     `gorm:"id"`
+	ContentID    int64     `gorm:"content_id"`
+	Content      *Content  `gorm:"foreignKey:ContentID"`
+	CorrectedAt  time.Time `gorm:"corrected_at"`
+	WhatWasWrong string    `gorm:"what_was_wrong"`
+	WhatChanged  string    `gorm:"what_changed"`
+	Severity     string    `gorm:"severity"`
+	CreatedByID  *int64    `gorm:"created_by_id"`
+	CreatedBy    *User     `gorm:"foreignKey:CreatedByID"`
+	CreatedAt    time.Time `gorm:"created_at"`
+}
@@ -7,16 +7,26 @@ import (
 type Content struct {
 	ID 			int64			`gorm:"id"`
 	Title 		string			`gorm:"title"`
+	Slug 		string			`gorm:"slug"`
 	Excerpt 	string			`gorm:"excerpt"`
 	Description string			`gorm:"description"`
 	Image 		string			`gorm:"image"`
-	Tags 		string			`gorm:"tags"`
 	Status 		string			`gorm:"status"`
 	IsValid 	string			`gorm:"is_valid"`
 	CategoryID	int64			`gorm:"category_id"`
 	CreatedByID	int64			`gorm:"created_by_id"`
 	User 		User			`gorm:"foreignKey:CreatedByID"`
 	Category 	Category		`gorm:"foreignKey:CategoryID"`
+	FactCheck	*FactCheck		`gorm:"foreignKey:ContentID"`
+	Sources		[]ContentSource	`gorm:"foreignKey:ContentID"`
+	Corrections	[]ContentCorrection	`gorm:"foreignKey:ContentID"`
+	PublishAt	*time.Time		`gorm:"publish_at"`
+	UnpublishAt	*time.Time		`gorm:"unpublish_at"`
 	CreatedAt 	time.Time		`gorm:"created_at"`
 	UpdatedAt	*time.Time		`gorm:"updated_at"`
+	ViewCount	int64	`gorm:"->;column:view_count"`
+	// only selected by searches
+	SearchTitle		string	`gorm:"->;column:search_title"`
+	SearchSnippet	string	`gorm:"->;column:search_snippet"`
+	SearchRank		float64	`gorm:"->;column:search_rank"`
 }
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type ContentReport struct {
+	ID            int64      `gorm:"id"`
+	ContentID     int64      `gorm:"content_id"`
+	Content       *Content   `gorm:"foreignKey:ContentID"`
+	Reason        string     `gorm:"reason"`
+	Details       string     `gorm:"details"`
+	Email         string     `gorm:"email"`
+	IPAddress     string     `gorm:"ip_address"`
+	UserAgent     string     `gorm:"user_agent"`
+	Status        string     `gorm:"status"`
+	ModeratorID   *int64     `gorm:"moderator_id"`
+	Moderator     *User      `gorm:"foreignKey:ModeratorID"`
+	ModeratorNote string     `gorm:"moderator_note"`
+	ResolvedAt    *time.Time `gorm:"resolved_at"`
+	CreatedAt     time.Time  `gorm:"created_at"`
+	UpdatedAt     *time.Time `gorm:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type ContentReview struct {
+	ID         int64     `gorm:"id"`
+	ContentID  int64     `gorm:"content_id"`
+	Action     string    `gorm:"action"`
+	FromStatus string    `gorm:"from_status"`
+	ToStatus   string    `gorm:"to_status"`
+	Comment    string    `gorm:"comment"`
+	ReviewerID *int64    `gorm:"reviewer_id"`
+	Reviewer   *User     `gorm:"foreignKey:ReviewerID"`
+	CreatedAt  time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type ContentRevision struct {
+	ID             int64     `gorm:"id"`
+	ContentID      int64     `gorm:"content_id"`
+	RevisionNumber int       `gorm:"revision_number"`
+	Title          string    `gorm:"title"`
+	Excerpt        string    `gorm:"excerpt"`
+	Description    string    `gorm:"description"`
+	Image          string    `gorm:"image"`
+	Tags           string    `gorm:"tags"`
+	Sources        *string   `gorm:"sources"`
+	Status         string    `gorm:"status"`
+	IsValid        string    `gorm:"is_valid"`
+	CategoryID     int64     `gorm:"category_id"`
+	EditedByID     *int64    `gorm:"edited_by_id"`
+	EditedBy       *User     `gorm:"foreignKey:EditedByID"`
+	RestoredFrom   *int      `gorm:"restored_from"`
+	CreatedAt      time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type ContentSource struct {
+	ID         int64      `gorm:"id"`
+	ContentID  int64      `gorm:"content_id"`
+	Url        string     `gorm:"url"`
+	Domain     string     `gorm:"domain"`
+	Publisher  string     `gorm:"publisher"`
+	AccessedAt *time.Time `gorm:"accessed_at"`
+	ArchiveUrl string     `gorm:"archive_url"`
+	Note       string     `gorm:"note"`
+	SortOrder  int        `gorm:"sort_order"`
+	CreatedAt  time.Time  `gorm:"created_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type FactCheck struct {
+	ID            int64      `gorm:"id"`
+	ContentID     int64      `gorm:"content_id"`
+	Claim         string     `gorm:"claim"`
+	Claimant      string     `gorm:"claimant"`
+	Rating        string     `gorm:"rating"`
+	ReviewerID    *int64     `gorm:"reviewer_id"`
+	Reviewer      *User      `gorm:"foreignKey:ReviewerID"`
+	ReviewedAt    time.Time  `gorm:"reviewed_at"`
+	EvidenceLinks string     `gorm:"evidence_links"`
+	CreatedAt     time.Time  `gorm:"created_at"`
+	UpdatedAt     *time.Time `gorm:"updated_at"`
+}
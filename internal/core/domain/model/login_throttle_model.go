@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type LoginThrottle struct {
+	Scope         string     `gorm:"primaryKey;column:scope"`
+	Identifier    string     `gorm:"primaryKey;column:identifier"`
+	Failures      int        `gorm:"failures"`
+	LastFailureAt time.Time  `gorm:"last_failure_at"`
+	LockedUntil   *time.Time `gorm:"locked_until"`
+}
+
+type LockoutEvent struct {
+	ID          int64     `gorm:"id"`
+	Scope       string    `gorm:"scope"`
+	Identifier  string    `gorm:"identifier"`
+	UserID      *int64    `gorm:"user_id"`
+	IPAddress   string    `gorm:"ip_address"`
+	Failures    int       `gorm:"failures"`
+	LockedUntil time.Time `gorm:"locked_until"`
+	CreatedAt   time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type RefreshToken struct {
+	ID              int64      `gorm:"id"`
+	UserID          int64      `gorm:"user_id"`
+	FamilyID        string     `gorm:"family_id"`
+	TokenHash       string     `gorm:"token_hash"`
+	AccessTokenID   string     `gorm:"access_token_id"`
+	AccessExpiresAt time.Time  `gorm:"access_expires_at"`
+	ExpiresAt       time.Time  `gorm:"expires_at"`
+	UsedAt          *time.Time `gorm:"used_at"`
+	RevokedAt       *time.Time `gorm:"revoked_at"`
+	CreatedAt       time.Time  `gorm:"created_at"`
+}
+
+type RevokedToken struct {
+	Jti       string    `gorm:"primaryKey;column:jti"`
+	ExpiresAt time.Time `gorm:"expires_at"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type ContentSlugHistory struct {
+	ID        int64     `gorm:"id"`
+	ContentID int64     `gorm:"content_id"`
+	Slug      string    `gorm:"slug"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
+
+type CategorySlugHistory struct {
+	ID         int64     `gorm:"id"`
+	CategoryID int64     `gorm:"category_id"`
+	Slug       string    `gorm:"slug"`
+	CreatedAt  time.Time `gorm:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type Tag struct {
+	ID         int64      `gorm:"id"`
+	Name       string     `gorm:"name"`
+	Slug       string     `gorm:"slug"`
+	UsageCount int        `gorm:"usage_count"`
+	CreatedAt  time.Time  `gorm:"created_at"`
+	UpdatedAt  *time.Time `gorm:"updated_at"`
+}
+
+type ContentTag struct {
+	ContentID int64 `gorm:"content_id"`
+	TagID     int64 `gorm:"tag_id"`
+	Position  int   `gorm:"position"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type RecoveryCode struct {
+	ID        int64      `gorm:"id"`
+	UserID    int64      `gorm:"user_id"`
+	CodeHash  string     `gorm:"code_hash"`
+	UsedAt    *time.Time `gorm:"used_at"`
+	CreatedAt time.Time  `gorm:"created_at"`
+}
+
+type MfaChallenge struct {
+	ID        int64      `gorm:"id"`
+	UserID    int64      `gorm:"user_id"`
+	TokenHash string     `gorm:"token_hash"`
+	Attempts  int        `gorm:"attempts"`
+	ExpiresAt time.Time  `gorm:"expires_at"`
+	UsedAt    *time.Time `gorm:"used_at"`
+	CreatedAt time.Time  `gorm:"created_at"`
+}
+
+type TwoFactorRequiredRole struct {
+	Role      string    `gorm:"primaryKey;column:role"`
+	CreatedAt time.Time `gorm:"created_at"`
+}
@@ -7,6 +7,13 @@ type User struct {
 	Name 		string		`gorm:"name"`
 	Email 		string		`gorm:"email"`
 	Password 	string		`gorm:"password"`
+	Role		string		`gorm:"role"`
+	IsActive	bool		`gorm:"is_active"`
+	DeactivatedAt	*time.Time	`gorm:"deactivated_at"`
+	TotpSecret	string		`gorm:"totp_secret"`
+	TotpEnabled	bool		`gorm:"totp_enabled"`
+	TotpLastStep	int64		`gorm:"totp_last_step"`
+	SessionsRevokedAt	*time.Time	`gorm:"sessions_revoked_at"`
 	CreatedAt 	time.Time	`gorm:"created_at"`
 	UpdatedAt	*time.Time	`gorm:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/lib/diff"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, action string, entityType string, entityID int64, before, after map[string]interface{})
+	GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]entity.AuditLogEntity, int64, int64, error)
+}
+
+type auditService struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// Record implements AuditService. The actor and request details are taken from
+// the request context, which carries the fiber locals set by the middlewares.
+// A failing audit write is logged but does not undo the mutation.
+func (a *auditService) Record(ctx context.Context, action string, entityType string, entityID int64, before, after map[string]interface{}) {
+	changes := diff.Compare(before, after)
+	if action == entity.AuditActionUpdate && len(changes) == 0 {
+		return
+	}
+
+	auditLog := entity.AuditLogEntity{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+
+	if principal, ok := ctx.Value("user").(*entity.Principal); ok {
+		auditLog.ActorID = &principal.UserID
+		if principal.IsApiKey() {
+			auditLog.ApiKeyID = &principal.ApiKeyID
+		}
+	}
+
+	if meta, ok := ctx.Value(entity.RequestMetaKey).(entity.RequestMeta); ok {
+		auditLog.IPAddress = meta.IPAddress
+		auditLog.UserAgent = meta.UserAgent
+	}
+
+	err := a.auditLogRepo.CreateAuditLog(ctx, auditLog)
+	if err != nil {
+		code := "[SERVICE] Record - 1"
+		log.Errorw(code, err, "action", action, "entity_type", entityType, "entity_id", entityID)
+	}
+}
+
+// GetAuditLogs implements AuditService.
+func (a *auditService) GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]entity.AuditLogEntity, int64, int64, error) {
+	results, totalData, totalPages, err := a.auditLogRepo.GetAuditLogs(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetAuditLogs - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+func NewAuditService(auditLogRepo repository.AuditLogRepository) AuditService {
+	return &auditService{
+		auditLogRepo: auditLogRepo,
+	}
+}
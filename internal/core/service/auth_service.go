@@ -2,73 +2,611 @@ package service
 
 import (
 	"trustnews/config"
+	"trustnews/internal/adapter/mailer"
 	"trustnews/internal/adapter/repository"
 	"trustnews/internal/core/domain/entity"
 	"trustnews/lib/auth"
 	"trustnews/lib/conv"
 	"context"
+	"fmt"
+	"strings"
 	"time"
 	"errors"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var err error
 var code string
 
+const mfaChallengeTTL = 5 * time.Minute
+const mfaChallengeMaxAttempts = 5
+
+// dummyPasswordHash is compared against when the email is unknown, so a
+// missing account takes as long to reject as a wrong password.
+var dummyPasswordHash, _ = conv.HashPassword("trustnews-dummy-password")
+
 type AuthService interface {
 	GetUserByEmail(ctx context.Context, req entity.LoginRequest) (*entity.AccessToken, error)
+	VerifyTwoFactorLogin(ctx context.Context, mfaToken string, otp string, ipAddress string) (*entity.AccessToken, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*entity.AccessToken, error)
+	Logout(ctx context.Context, claims entity.JwtData) error
+	GetJwks(ctx context.Context) []entity.JwkEntity
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) error
 }
 
 type authService struct {
 	authRepository repository.AuthRepository
+	twoFactorRepo repository.TwoFactorRepository
+	loginThrottleRepo repository.LoginThrottleRepository
 	cfg *config.Config
 	jwtToken auth.Jwt
+	mailer mailer.Mailer
 }
 
+// GetUserByEmail is the first login step. Unknown emails and wrong passwords
+// both end in ErrInvalidCredentials so the response does not reveal which
+// accounts exist, and every failure counts towards the IP and account limits.
 func (a *authService) GetUserByEmail(ctx context.Context, req entity.LoginRequest) (*entity.AccessToken, error) {
-	result, err := a.authRepository.GetUserByEmail(ctx, req)
+	account := normalizeLoginAccount(req.Email)
+	err = a.checkLoginThrottle(ctx, req.IPAddress, account)
 	if err != nil {
 		code = "[SERVICE] GetUserByEmail - 1"
 		log.Errorw(code, err)
 		return nil, err
 	}
 
-	if checkPass := conv.CheckPasswordHash(req.Password, result.Password); !checkPass {
+	result, err := a.authRepository.GetUserByEmail(ctx, req)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		code = "[SERVICE] GetUserByEmail - 2"
-		err = errors.New("Invalid Password")
 		log.Errorw(code, err)
 		return nil, err
 	}
 
+	if result == nil {
+		// Spend the same bcrypt time as for a real account.
+		conv.CheckPasswordHash(req.Password, dummyPasswordHash)
+		return nil, a.registerLoginFailure(ctx, req.IPAddress, account, nil)
+	}
+
+	if checkPass := conv.CheckPasswordHash(req.Password, result.Password); !checkPass {
+		code = "[SERVICE] GetUserByEmail - 3"
+		log.Errorw(code, ErrInvalidCredentials)
+		return nil, a.registerLoginFailure(ctx, req.IPAddress, account, &result.ID)
+	}
+
+	if !result.IsActive {
+		code = "[SERVICE] GetUserByEmail - 4"
+		log.Errorw(code, ErrUserInactive)
+		return nil, ErrUserInactive
+	}
+
+	// Users with 2FA only get a short lived challenge here, the tokens are
+	// issued by VerifyTwoFactorLogin once the second factor checks out. The
+	// account counter is kept until then so guessing codes is throttled too.
+	if result.TwoFactorEnabled {
+		resp, err := a.createMfaChallenge(ctx, result.ID)
+		if err != nil {
+			code = "[SERVICE] GetUserByEmail - 5"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		return resp, nil
+	}
+
+	err = a.loginThrottleRepo.ClearFailures(ctx, entity.ThrottleScopeAccount, account)
+	if err != nil {
+		code = "[SERVICE] GetUserByEmail - 6"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp, err := a.issueTokens(ctx, *result, uuid.NewString())
+	if err != nil {
+		code = "[SERVICE] GetUserByEmail - 7"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// VerifyTwoFactorLogin is the second login step, it trades the challenge token
+// from the first step plus a TOTP or recovery code for the real tokens. Wrong
+// codes count as failed logins for the account and the IP.
+func (a *authService) VerifyTwoFactorLogin(ctx context.Context, mfaToken string, otp string, ipAddress string) (*entity.AccessToken, error) {
+	challenge, err := a.twoFactorRepo.GetMfaChallengeByHash(ctx, conv.HashToken(mfaToken))
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 1"
+		log.Errorw(code, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidMfaToken
+		}
+		return nil, err
+	}
+
+	if challenge.UsedAt != nil || challenge.Attempts >= mfaChallengeMaxAttempts || time.Now().After(challenge.ExpiresAt) {
+		code = "[SERVICE] VerifyTwoFactorLogin - 2"
+		log.Errorw(code, ErrInvalidMfaToken)
+		return nil, ErrInvalidMfaToken
+	}
+
+	user, err := a.authRepository.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	account := normalizeLoginAccount(user.Email)
+	err = a.checkLoginThrottle(ctx, ipAddress, account)
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	valid, err := verifyTwoFactorCode(ctx, a.twoFactorRepo, challenge.UserID, otp)
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 5"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !valid {
+		if err := a.twoFactorRepo.IncrementMfaChallengeAttempts(ctx, challenge.ID); err != nil {
+			code = "[SERVICE] VerifyTwoFactorLogin - 6"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		if err := a.registerLoginFailure(ctx, ipAddress, account, &user.ID); !errors.Is(err, ErrInvalidCredentials) {
+			return nil, err
+		}
+
+		return nil, ErrInvalidMfaCode
+	}
+
+	marked, err := a.twoFactorRepo.MarkMfaChallengeUsed(ctx, challenge.ID)
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 7"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !marked {
+		return nil, ErrInvalidMfaToken
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	err = a.loginThrottleRepo.ClearFailures(ctx, entity.ThrottleScopeAccount, account)
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 8"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	resp, err := a.issueTokens(ctx, *user, uuid.NewString())
+	if err != nil {
+		code = "[SERVICE] VerifyTwoFactorLogin - 9"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// checkLoginThrottle returns a LoginLockedError while either the IP or the
+// account is locked out or still inside its backoff delay.
+func (a *authService) checkLoginThrottle(ctx context.Context, ipAddress string, account string) error {
+	var retryAfter time.Duration
+	for scope, identifier := range map[string]string{entity.ThrottleScopeIP: ipAddress, entity.ThrottleScopeAccount: account} {
+		if identifier == "" {
+			continue
+		}
+
+		lockedUntil, err := a.loginThrottleRepo.GetLockedUntil(ctx, scope, identifier)
+		if err != nil {
+			return err
+		}
+
+		if lockedUntil != nil && time.Until(*lockedUntil) > retryAfter {
+			retryAfter = time.Until(*lockedUntil)
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// registerLoginFailure counts a failed attempt for the IP and the account. The
+// second half of the allowed attempts is slowed down with an exponential delay,
+// the last one locks the identifier out and is recorded as a lockout event. It
+// returns ErrInvalidCredentials unless storing the attempt failed.
+func (a *authService) registerLoginFailure(ctx context.Context, ipAddress string, account string, userID *int64) error {
+	limits := map[string]int{
+		entity.ThrottleScopeIP: a.cfg.App.LoginIpMaxAttempts,
+		entity.ThrottleScopeAccount: a.cfg.App.LoginMaxAttempts,
+	}
+
+	for scope, identifier := range map[string]string{entity.ThrottleScopeIP: ipAddress, entity.ThrottleScopeAccount: account} {
+		if identifier == "" {
+			continue
+		}
+
+		failures, err := a.loginThrottleRepo.RegisterFailure(ctx, scope, identifier, a.cfg.App.LoginAttemptWindow)
+		if err != nil {
+			code = "[SERVICE] registerLoginFailure - 1"
+			log.Errorw(code, err)
+			return err
+		}
+
+		delay, lockout := loginBackoff(failures, limits[scope], a.cfg.App.LoginBackoffBase, a.cfg.App.LoginLockoutDuration)
+		if delay == 0 {
+			continue
+		}
+
+		lockedUntil := time.Now().Add(delay)
+		err = a.loginThrottleRepo.LockLogin(ctx, scope, identifier, lockedUntil)
+		if err != nil {
+			code = "[SERVICE] registerLoginFailure - 2"
+			log.Errorw(code, err)
+			return err
+		}
+
+		if !lockout {
+			continue
+		}
+
+		event := entity.LockoutEventEntity{
+			Scope: scope,
+			Identifier: identifier,
+			IPAddress: ipAddress,
+			Failures: failures,
+			LockedUntil: lockedUntil,
+		}
+		if scope == entity.ThrottleScopeAccount {
+			event.UserID = userID
+		}
+
+		err = a.loginThrottleRepo.CreateLockoutEvent(ctx, event)
+		if err != nil {
+			code = "[SERVICE] registerLoginFailure - 3"
+			log.Errorw(code, err)
+			return err
+		}
+
+		log.Warnw("[SERVICE] login locked out", "scope", scope, "identifier", identifier, "failures", failures)
+	}
+
+	return ErrInvalidCredentials
+}
+
+// loginBackoff returns how long the identifier has to wait after its n-th
+// failure and whether that wait is a full lockout.
+func loginBackoff(failures int, maxAttempts int, base time.Duration, lockout time.Duration) (time.Duration, bool) {
+	if failures >= maxAttempts {
+		return lockout, true
+	}
+
+	free := maxAttempts / 2
+	if failures <= free {
+		return 0, false
+	}
+
+	delay := base << (failures - free - 1)
+	if delay <= 0 || delay > lockout {
+		delay = lockout
+	}
+
+	return delay, false
+}
+
+func normalizeLoginAccount(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// RefreshToken rotates a refresh token. Every refresh token can be used once,
+// presenting one that was already used means it leaked, so the whole family
+// (and the access tokens issued with it) gets revoked.
+func (a *authService) RefreshToken(ctx context.Context, refreshToken string) (*entity.AccessToken, error) {
+	current, err := a.authRepository.GetRefreshTokenByHash(ctx, conv.HashToken(refreshToken))
+	if err != nil {
+		code = "[SERVICE] RefreshToken - 1"
+		log.Errorw(code, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.UsedAt != nil || current.RevokedAt != nil {
+		return nil, a.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		code = "[SERVICE] RefreshToken - 2"
+		log.Errorw(code, ErrInvalidRefreshToken)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	marked, err := a.authRepository.MarkRefreshTokenUsed(ctx, current.ID)
+	if err != nil {
+		code = "[SERVICE] RefreshToken - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !marked {
+		return nil, a.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	user, err := a.authRepository.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		code = "[SERVICE] RefreshToken - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !user.IsActive {
+		code = "[SERVICE] RefreshToken - 5"
+		log.Errorw(code, ErrUserInactive)
+		return nil, ErrUserInactive
+	}
+
+	resp, err := a.issueTokens(ctx, *user, current.FamilyID)
+	if err != nil {
+		code = "[SERVICE] RefreshToken - 6"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Logout revokes the access token of the request and the refresh token family
+// it was issued with.
+func (a *authService) Logout(ctx context.Context, claims entity.JwtData) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		code = "[SERVICE] Logout - 1"
+		log.Errorw(code, ErrInvalidRefreshToken)
+		return ErrInvalidRefreshToken
+	}
+
+	err = a.authRepository.RevokeAccessToken(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		code = "[SERVICE] Logout - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	session, err := a.authRepository.GetRefreshTokenByAccessTokenID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		code = "[SERVICE] Logout - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = a.authRepository.RevokeTokenFamily(ctx, session.FamilyID)
+	if err != nil {
+		code = "[SERVICE] Logout - 4"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetJwks returns the public keys partners use to verify our access tokens.
+func (a *authService) GetJwks(ctx context.Context) []entity.JwkEntity {
+	return a.jwtToken.Jwks()
+}
+
+// ForgotPassword never tells the caller whether the email exists, the reset
+// mail is only sent when it belongs to an active user.
+func (a *authService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := a.authRepository.GetUserByEmail(ctx, entity.LoginRequest{Email: email})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		code = "[SERVICE] ForgotPassword - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := conv.GenerateRandomToken(32)
+	if err != nil {
+		code = "[SERVICE] ForgotPassword - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	expiresAt := time.Now().Add(a.cfg.App.PasswordResetTTL)
+	err = a.authRepository.CreatePasswordReset(ctx, entity.PasswordResetEntity{
+		UserID: user.ID,
+		TokenHash: conv.HashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		code = "[SERVICE] ForgotPassword - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	mail := entity.MailEntity{
+		To: user.Email,
+		Subject: "Reset your Trust News password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s and can only be used once.\n\n%s/reset-password?token=%s\n\nIf you did not ask for this, you can ignore this email.",
+			user.Name, a.cfg.App.PasswordResetTTL, strings.TrimRight(a.cfg.App.FrontendUrl, "/"), token),
+	}
+
+	// Sent in the background so a slow mail server does not hold up the response.
+	// Unknown accounts still return before the reset is stored, so this narrows
+	// the timing difference rather than hiding it.
+	go func() {
+		if err := a.mailer.Send(context.Background(), mail); err != nil {
+			code := "[SERVICE] ForgotPassword - 4"
+			log.Errorw(code, err)
+		}
+	}()
+
+	return nil
+}
+
+func (a *authService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	reset, err := a.authRepository.GetPasswordResetByHash(ctx, conv.HashToken(token))
+	if err != nil {
+		code = "[SERVICE] ResetPassword - 1"
+		log.Errorw(code, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		code = "[SERVICE] ResetPassword - 2"
+		log.Errorw(code, ErrInvalidResetToken)
+		return ErrInvalidResetToken
+	}
+
+	password, err := conv.HashPassword(newPassword)
+	if err != nil {
+		code = "[SERVICE] ResetPassword - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	consumed, err := a.authRepository.ConsumePasswordReset(ctx, reset.ID, reset.UserID, password)
+	if err != nil {
+		code = "[SERVICE] ResetPassword - 4"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !consumed {
+		return ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+func (a *authService) createMfaChallenge(ctx context.Context, userID int64) (*entity.AccessToken, error) {
+	mfaToken, err := conv.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(mfaChallengeTTL)
+	err = a.twoFactorRepo.CreateMfaChallenge(ctx, entity.MfaChallengeEntity{
+		UserID: userID,
+		TokenHash: conv.HashToken(mfaToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AccessToken{
+		MfaRequired: true,
+		MfaToken: mfaToken,
+		MfaExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// issueTokens creates an access and refresh token pair. When the role of the
+// user requires 2FA and it is not set up yet, the access token is flagged so it
+// only works on the 2FA enrollment endpoints.
+func (a *authService) issueTokens(ctx context.Context, user entity.UserEntity, familyID string) (*entity.AccessToken, error) {
+	setupRequired := false
+	if !user.TwoFactorEnabled {
+		setupRequired, err = a.twoFactorRepo.IsTwoFactorRequired(ctx, user.Role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	jwtData := entity.JwtData{
-		UserID: float64(result.ID),
+		UserID: float64(user.ID),
+		Role: user.Role,
+		MfaSetupRequired: setupRequired,
 		RegisteredClaims: jwt.RegisteredClaims{
-			NotBefore: jwt.NewNumericDate(time.Now().Add(time.Hour *2)),
-			ID: string(result.ID),
+			ID: uuid.NewString(),
 		},
 	}
 
 	accessToken, expiresAt, err := a.jwtToken.GenerateToken(&jwtData)
 	if err != nil {
-		code = "[SERVICE] GetUserByEmail - 3"
-		log.Errorw(code, err)
+		return nil, err
+	}
+
+	refreshToken, err := conv.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshExpiresAt := time.Now().Add(a.cfg.App.JwtRefreshTokenTTL)
+	err = a.authRepository.CreateRefreshToken(ctx, entity.RefreshTokenEntity{
+		UserID: user.ID,
+		FamilyID: familyID,
+		TokenHash: conv.HashToken(refreshToken),
+		AccessTokenID: jwtData.ID,
+		AccessExpiresAt: time.Unix(expiresAt, 0),
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	resp := entity.AccessToken {
 		AccessToken: accessToken,
 		ExpiresAt: expiresAt,
+		RefreshToken: refreshToken,
+		RefreshExpiresAt: refreshExpiresAt.Unix(),
+		MfaSetupRequired: setupRequired,
 	}
 
 	return &resp, nil
 }
 
-func NewAuthService(authRepository repository.AuthRepository, cfg *config.Config, jwtToken auth.Jwt) AuthService {
+func (a *authService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	code = "[SERVICE] RefreshToken - reuse"
+	log.Errorw(code, ErrRefreshTokenReused)
+
+	if err := a.authRepository.RevokeTokenFamily(ctx, familyID); err != nil {
+		log.Errorw(code, err)
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+func NewAuthService(authRepository repository.AuthRepository, twoFactorRepo repository.TwoFactorRepository, loginThrottleRepo repository.LoginThrottleRepository, cfg *config.Config, jwtToken auth.Jwt, mailer mailer.Mailer) AuthService {
 	return &authService{
 		authRepository: authRepository,
+		twoFactorRepo: twoFactorRepo,
+		loginThrottleRepo: loginThrottleRepo,
 		cfg: cfg,
 		jwtToken: jwtToken,
+		mailer: mailer,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/lib/conv"
+
+	"gorm.io/gorm"
+)
+
+func TestLoginBackoff(t *testing.T) {
+	const base = time.Second
+	const lockout = 15 * time.Minute
+
+	tests := []struct {
+		name        string
+		failures    int
+		maxAttempts int
+		wantDelay   time.Duration
+		wantLockout bool
+	}{
+		{name: "no failures", failures: 0, maxAttempts: 10, wantDelay: 0},
+		{name: "first half is free", failures: 5, maxAttempts: 10, wantDelay: 0},
+		{name: "first delayed failure", failures: 6, maxAttempts: 10, wantDelay: time.Second},
+		{name: "delay doubles", failures: 7, maxAttempts: 10, wantDelay: 2 * time.Second},
+		{name: "last delay before lockout", failures: 9, maxAttempts: 10, wantDelay: 8 * time.Second},
+		{name: "max attempts locks out", failures: 10, maxAttempts: 10, wantDelay: lockout, wantLockout: true},
+		{name: "past max attempts stays locked out", failures: 25, maxAttempts: 10, wantDelay: lockout, wantLockout: true},
+		{name: "delay is capped at the lockout", failures: 45, maxAttempts: 60, wantDelay: lockout},
+		{name: "shift overflow falls back to the lockout", failures: 150, maxAttempts: 200, wantDelay: lockout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, locked := loginBackoff(tt.failures, tt.maxAttempts, base, lockout)
+			if delay != tt.wantDelay || locked != tt.wantLockout {
+				t.Errorf("loginBackoff(%d, %d) = %s, %v, want %s, %v", tt.failures, tt.maxAttempts, delay, locked, tt.wantDelay, tt.wantLockout)
+			}
+		})
+	}
+}
+
+// fakeAuthRepository keeps the refresh tokens of a single user in memory, the
+// methods RefreshToken does not use are left to the embedded nil interface.
+type fakeAuthRepository struct {
+	repository.AuthRepository
+	user          entity.UserEntity
+	tokens        []*entity.RefreshTokenEntity
+	revokedAccess map[string]bool
+	loseRace      bool
+}
+
+func (f *fakeAuthRepository) GetUserByID(ctx context.Context, id int64) (*entity.UserEntity, error) {
+	user := f.user
+	return &user, nil
+}
+
+func (f *fakeAuthRepository) CreateRefreshToken(ctx context.Context, req entity.RefreshTokenEntity) error {
+	req.ID = int64(len(f.tokens) + 1)
+	f.tokens = append(f.tokens, &req)
+	return nil
+}
+
+func (f *fakeAuthRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshTokenEntity, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash == tokenHash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeAuthRepository) MarkRefreshTokenUsed(ctx context.Context, id int64) (bool, error) {
+	if f.loseRace {
+		return false, nil
+	}
+	for _, token := range f.tokens {
+		if token.ID == id && token.UsedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeAuthRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range f.tokens {
+		if token.FamilyID == familyID {
+			token.RevokedAt = &now
+			f.revokedAccess[token.AccessTokenID] = true
+		}
+	}
+	return nil
+}
+
+type fakeJwt struct {
+	issued int
+}
+
+func (f *fakeJwt) GenerateToken(data *entity.JwtData) (string, int64, error) {
+	f.issued++
+	return "access-" + data.ID, time.Now().Add(time.Hour).Unix(), nil
+}
+
+func (f *fakeJwt) VerifyAccessToken(token string) (*entity.JwtData, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeJwt) Jwks() []entity.JwkEntity {
+	return nil
+}
+
+func newRefreshTestService(t *testing.T) (*authService, *fakeAuthRepository, string) {
+	t.Helper()
+
+	repo := &fakeAuthRepository{
+		user:          entity.UserEntity{ID: 7, Role: "Editor", IsActive: true, TwoFactorEnabled: true},
+		revokedAccess: map[string]bool{},
+	}
+
+	cfg := &config.Config{}
+	cfg.App.JwtRefreshTokenTTL = time.Hour
+
+	svc := &authService{authRepository: repo, cfg: cfg, jwtToken: &fakeJwt{}}
+
+	first, err := svc.issueTokens(context.Background(), repo.user, "family-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return svc, repo, first.RefreshToken
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	svc, repo, first := newRefreshTestService(t)
+
+	second, err := svc.RefreshToken(context.Background(), first)
+	if err != nil {
+		t.Fatalf("RefreshToken = %v", err)
+	}
+	if second.RefreshToken == first {
+		t.Fatal("RefreshToken returned the presented refresh token")
+	}
+
+	rotated, _ := repo.GetRefreshTokenByHash(context.Background(), conv.HashToken(second.RefreshToken))
+	if rotated.FamilyID != "family-1" || rotated.UsedAt != nil || rotated.RevokedAt != nil {
+		t.Errorf("rotated refresh token = %+v, want an unused token of family-1", rotated)
+	}
+
+	if len(repo.revokedAccess) != 0 {
+		t.Errorf("rotation revoked access tokens %v", repo.revokedAccess)
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	svc, repo, first := newRefreshTestService(t)
+	ctx := context.Background()
+
+	second, err := svc.RefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("RefreshToken = %v", err)
+	}
+
+	_, err = svc.RefreshToken(ctx, first)
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("reusing a refresh token = %v, want ErrRefreshTokenReused", err)
+	}
+
+	for _, token := range repo.tokens {
+		if token.RevokedAt == nil {
+			t.Errorf("refresh token %d of the family was not revoked", token.ID)
+		}
+		if !repo.revokedAccess[token.AccessTokenID] {
+			t.Errorf("access token %s of the family was not revoked", token.AccessTokenID)
+		}
+	}
+
+	_, err = svc.RefreshToken(ctx, second.RefreshToken)
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("refreshing with the rotated token after reuse = %v, want ErrRefreshTokenReused", err)
+	}
+}
+
+func TestRefreshTokenLostRaceRevokesFamily(t *testing.T) {
+	svc, repo, first := newRefreshTestService(t)
+	repo.loseRace = true
+
+	_, err := svc.RefreshToken(context.Background(), first)
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("RefreshToken = %v, want ErrRefreshTokenReused", err)
+	}
+	if repo.tokens[0].RevokedAt == nil {
+		t.Error("the family was not revoked when the token was used concurrently")
+	}
+}
+
+func TestRefreshTokenRejectsUnknownAndExpired(t *testing.T) {
+	svc, repo, first := newRefreshTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.RefreshToken(ctx, "unknown")
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("unknown refresh token = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	repo.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = svc.RefreshToken(ctx, first)
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expired refresh token = %v, want ErrInvalidRefreshToken", err)
+	}
+	if repo.tokens[0].RevokedAt != nil || repo.tokens[0].UsedAt != nil {
+		t.Error("an expired refresh token was used or revoked")
+	}
+}
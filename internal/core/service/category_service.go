@@ -2,16 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 	"trustnews/internal/adapter/repository"
 	"trustnews/internal/core/domain/entity"
-	"trustnews/lib/conv"
+	"trustnews/lib/slug"
 
 	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
 )
 
 type CategoryService interface {
 	GetCategories(ctx context.Context)([]entity.CategoryEntity, error)
+	GetCategoryTree(ctx context.Context)([]entity.CategoryEntity, error)
 	GetCategoryByID(ctx context.Context, id int64)(*entity.CategoryEntity, error)
+	GetCategoryBySlug(ctx context.Context, slug string)(*entity.CategoryEntity, error)
 	CreateCategory(ctx context.Context, req entity.CategoryEntity) error
 	EditCategoryByID(ctx context.Context, req entity.CategoryEntity) error
 	DeleteCategory(ctx context.Context, id int64) error
@@ -19,30 +23,57 @@ type CategoryService interface {
 
 type categoryService struct {
 	categoryRepository repository.CategoryRepository
+	auditService AuditService
+	slugifier *slug.Slugifier
+	searchService SearchService
 }
 
 func (c *categoryService) CreateCategory(ctx context.Context, req entity.CategoryEntity) error {
-	slug := conv.GenerateSlug(req.Title)
-	req.Slug = slug
-
-	err = c.categoryRepository.CreateCategory(ctx, req)
+	err := c.checkParent(ctx, req.ParentID)
 	if err != nil {
 		code = "[SERVICE] CreateCategory - 1"
 		log.Errorw(code, err)
 		return err
 	}
 
+	req.Slug = c.slugifier.Make(req.Title)
+
+	id, err := c.categoryRepository.CreateCategory(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateCategory - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	created, err := c.categoryRepository.GetCategoryByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] CreateCategory - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionCreate, entity.AuditEntityCategory, id, nil, categoryAuditSnapshot(*created))
+
 	return nil
 }
 
 func (c *categoryService) DeleteCategory(ctx context.Context, id int64) error {
-	err = c.categoryRepository.DeleteCategory(ctx, id)
+	current, err := c.categoryRepository.GetCategoryByID(ctx, id)
 	if err != nil {
 		code = "[SERVICE] DeleteCategory - 1"
 		log.Errorw(code, err)
 		return err
 	}
 
+	err = c.categoryRepository.DeleteCategory(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteCategory - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityCategory, id, categoryAuditSnapshot(*current), nil)
+
 	return nil
 }
 
@@ -54,20 +85,42 @@ func (c *categoryService) EditCategoryByID(ctx context.Context, req entity.Categ
 		return err
 	}
 
-	slug := conv.GenerateSlug(req.Title)
-	if categoryData.Title == req.Title {
-		slug = categoryData.Slug
+	if req.ParentID != nil && *req.ParentID == req.ID {
+		code = "[SERVICE] EditCategoryByID - 2"
+		log.Errorw(code, ErrCategoryCycle)
+		return ErrCategoryCycle
 	}
 
-	req.Slug = slug
+	err = c.checkParent(ctx, req.ParentID)
+	if err != nil {
+		code = "[SERVICE] EditCategoryByID - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	req.Slug = categoryData.Slug
+	if categoryData.Title != req.Title {
+		req.Slug = c.slugifier.Make(req.Title)
+	}
 
 	err = c.categoryRepository.EditCategoryByID(ctx, req)
 	if err != nil {
-		code = "[SERVICE] EditCategoryByID - 2"
+		code = "[SERVICE] EditCategoryByID - 4"
+		log.Errorw(code, err)
+		return err
+	}
+
+	updated, err := c.categoryRepository.GetCategoryByID(ctx, req.ID)
+	if err != nil {
+		code = "[SERVICE] EditCategoryByID - 5"
 		log.Errorw(code, err)
 		return err
 	}
 
+	c.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityCategory, req.ID, categoryAuditSnapshot(*categoryData), categoryAuditSnapshot(*updated))
+	// the contents of subcategories are indexed under this category as well
+	c.searchService.IndexContents(ctx, entity.QueryString{CategoryID: req.ID})
+
 	return nil
 }
 
@@ -93,6 +146,86 @@ func (c *categoryService) GetCategoryByID(ctx context.Context, id int64) (*entit
 	return result, nil
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryService {
-	return &categoryService{categoryRepository: categoryRepo}
+// GetCategoryTree implements CategoryService. Top level categories come first,
+// each level keeps the sort_order, title order of GetCategories.
+func (c *categoryService) GetCategoryTree(ctx context.Context) ([]entity.CategoryEntity, error) {
+	results, err := c.categoryRepository.GetCategories(ctx)
+	if err != nil {
+		code = "[SERVICE] GetCategoryTree - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	children := map[int64][]entity.CategoryEntity{}
+	for _, result := range results {
+		if result.ParentID != nil {
+			children[*result.ParentID] = append(children[*result.ParentID], result)
+		}
+	}
+
+	var build func(category entity.CategoryEntity) entity.CategoryEntity
+	build = func(category entity.CategoryEntity) entity.CategoryEntity {
+		for _, child := range children[category.ID] {
+			category.Children = append(category.Children, build(child))
+		}
+
+		return category
+	}
+
+	tree := []entity.CategoryEntity{}
+	for _, result := range results {
+		if result.ParentID == nil {
+			tree = append(tree, build(result))
+		}
+	}
+
+	return tree, nil
+}
+
+// checkParent makes sure a given parent category exists.
+func (c *categoryService) checkParent(ctx context.Context, parentID *int64) error {
+	if parentID == nil {
+		return nil
+	}
+
+	_, err := c.categoryRepository.GetCategoryByID(ctx, *parentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrParentNotFound
+	}
+
+	return err
+}
+
+// GetCategoryBySlug implements CategoryService.
+func (c *categoryService) GetCategoryBySlug(ctx context.Context, slug string) (*entity.CategoryEntity, error) {
+	result, err := c.categoryRepository.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		code = "[SERVICE] GetCategoryBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func categoryAuditSnapshot(category entity.CategoryEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"title":           category.Title,
+		"slug":            category.Slug,
+		"parent_id":       category.ParentID,
+		"sort_order":      category.SortOrder,
+		"description":     category.Description,
+		"seo_title":       category.SeoTitle,
+		"seo_description": category.SeoDescription,
+		"created_by_id":   category.User.ID,
+	}
+}
+
+func NewCategoryService(categoryRepo repository.CategoryRepository, auditService AuditService, slugifier *slug.Slugifier, searchService SearchService) CategoryService {
+	return &categoryService{
+		categoryRepository: categoryRepo,
+		auditService: auditService,
+		slugifier: slugifier,
+		searchService: searchService,
+	}
 }
\ No newline at end of file
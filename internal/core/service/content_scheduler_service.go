@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"time"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type ContentSchedulerService interface {
+	Start(ctx context.Context)
+	RunOnce(ctx context.Context) error
+}
+
+type contentSchedulerService struct {
+	contentRepo  repository.ContentRepository
+	auditService AuditService
+	searchService SearchService
+	interval     time.Duration
+}
+
+// Start implements ContentSchedulerService. It blocks until ctx is cancelled, so
+// run it in its own goroutine. Every instance may run it, the repository makes
+// sure a content is only moved once.
+func (c *contentSchedulerService) Start(ctx context.Context) {
+	if c.interval <= 0 {
+		log.Info("content scheduler disabled")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.RunOnce(ctx); err != nil {
+			code = "[SERVICE] Start - 1"
+			log.Errorw(code, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce implements ContentSchedulerService.
+func (c *contentSchedulerService) RunOnce(ctx context.Context) error {
+	applied, err := c.contentRepo.ApplyContentSchedule(ctx, time.Now())
+	if err != nil {
+		code = "[SERVICE] RunOnce - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	for _, review := range applied {
+		log.Infow("content schedule applied", "content_id", review.ContentID, "from", review.FromStatus, "to", review.ToStatus)
+		c.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityContent, review.ContentID,
+			map[string]interface{}{"status": review.FromStatus},
+			map[string]interface{}{"status": review.ToStatus})
+
+		content, err := c.contentRepo.GetContentByID(ctx, review.ContentID)
+		if err != nil {
+			code = "[SERVICE] RunOnce - 2"
+			log.Errorw(code, err)
+			continue
+		}
+		c.searchService.IndexContent(ctx, *content)
+	}
+
+	return nil
+}
+
+func NewContentSchedulerService(contentRepo repository.ContentRepository, auditService AuditService, searchService SearchService, cfg *config.Config) ContentSchedulerService {
+	return &contentSchedulerService{
+		contentRepo:  contentRepo,
+		auditService: auditService,
+		searchService: searchService,
+		interval:     cfg.App.SchedulerInterval,
+	}
+}
@@ -2,53 +2,162 @@ package service
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"trustnews/config"
 	"trustnews/internal/adapter/cloudflare"
 	"trustnews/internal/adapter/repository"
 	"trustnews/internal/core/domain/entity"
+	"trustnews/lib/diff"
+	"trustnews/lib/slug"
 
 	"github.com/gofiber/fiber/v2/log"
 )
 
 type ContentService interface {
 	GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, int64, error)
+	GetContentsByCursor(ctx context.Context, query entity.QueryString) (*entity.ContentPageEntity, error)
 	GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error)
+	GetContentBySlug(ctx context.Context, slug string) (*entity.ContentEntity, error)
 	CreateContent(ctx context.Context, req entity.ContentEntity) error
-	UpdateContent(ctx context.Context, req entity.ContentEntity) error
+	UpdateContent(ctx context.Context, req entity.ContentEntity, actor entity.UserEntity) error
 	DeleteContent(ctx context.Context, id int64) error
+	RecordView(ctx context.Context, id int64)
 	UploadImageR2(ctx context.Context, req entity.FileUploadEntity) (string, error)
+
+	GetRevisions(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.ContentRevisionEntity, int64, int64, error)
+	GetRevision(ctx context.Context, contentID int64, revisionNumber int) (*entity.ContentRevisionEntity, error)
+	GetRevisionDiff(ctx context.Context, contentID int64, from, to int) (*entity.ContentRevisionDiffEntity, error)
+	RestoreRevision(ctx context.Context, contentID int64, revisionNumber int, actor entity.UserEntity) (int, error)
+
+	TransitionContent(ctx context.Context, contentID int64, action, comment string, actor entity.UserEntity) (*entity.ContentEntity, error)
+	GetContentReviews(ctx context.Context, contentID int64) ([]entity.ContentReviewEntity, error)
+}
+
+// contentTransition describes one step of the editorial workflow.
+type contentTransition struct {
+	From           []string
+	To             string
+	Roles          []string
+	CommentNeeded bool
+}
+
+var contentTransitions = map[string]contentTransition{
+	entity.ContentActionSubmit: {
+		From:  []string{entity.ContentStatusDraft},
+		To:    entity.ContentStatusInReview,
+		Roles: []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleAuthor},
+	},
+	entity.ContentActionApprove: {
+		From:  []string{entity.ContentStatusInReview},
+		To:    entity.ContentStatusApproved,
+		Roles: []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleFactChecker},
+	},
+	entity.ContentActionReject: {
+		From:          []string{entity.ContentStatusInReview, entity.ContentStatusApproved},
+		To:            entity.ContentStatusDraft,
+		Roles:         []string{entity.RoleAdmin, entity.RoleEditor, entity.RoleFactChecker},
+		CommentNeeded: true,
+	},
+	entity.ContentActionSchedule: {
+		From:  []string{entity.ContentStatusApproved},
+		To:    entity.ContentStatusScheduled,
+		Roles: []string{entity.RoleAdmin, entity.RoleEditor},
+	},
+	entity.ContentActionPublish: {
+		From:  []string{entity.ContentStatusApproved, entity.ContentStatusScheduled},
+		To:    entity.ContentStatusPublished,
+		Roles: []string{entity.RoleAdmin, entity.RoleEditor},
+	},
+	entity.ContentActionArchive: {
+		From:  []string{entity.ContentStatusPublished, entity.ContentStatusScheduled},
+		To:    entity.ContentStatusArchived,
+		Roles: []string{entity.RoleAdmin, entity.RoleEditor},
+	},
+}
+
+// reviewedStatuses are past review. An edit by an author sends the content
+// back to review so the change cannot go live unchecked, editors and admins
+// may publish on their own and keep the status.
+var reviewedStatuses = []string{
+	entity.ContentStatusApproved,
+	entity.ContentStatusScheduled,
+	entity.ContentStatusPublished,
 }
 
 type contentService struct {
 	contentRepo repository.ContentRepository
 	cfg         *config.Config
 	r2          cloudflare.CloudflareR2Adapter
+	auditService AuditService
+	slugifier   *slug.Slugifier
+	searchService SearchService
 }
 
-// CreateContent implements ContentService.
+// CreateContent implements ContentService. New contents always start as drafts.
 func (c *contentService) CreateContent(ctx context.Context, req entity.ContentEntity) error {
-	err = c.contentRepo.CreateContent(ctx, req)
-	if err != nil  {
+	if !validContentSchedule(req) {
 		code = "[SERVICE] CreateContent - 1"
+		log.Errorw(code, ErrInvalidSchedule)
+		return ErrInvalidSchedule
+	}
+
+	req.Status = entity.ContentStatusDraft
+	req.IsValid = entity.ContentValidityValid
+	req.Slug = c.slugifier.Make(req.Title)
+	req.Tags = slugTags(c.slugifier, req.Tags)
+	id, err := c.contentRepo.CreateContent(ctx, req)
+	if err != nil  {
+		code = "[SERVICE] CreateContent - 2"
 		log.Errorw(code, err)
 		return err
 	}
 
+	created, err := c.contentRepo.GetContentByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] CreateContent - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionCreate, entity.AuditEntityContent, id, nil, contentAuditSnapshot(*created))
+	c.searchService.IndexContent(ctx, *created)
+
 	return nil
 }
 
 // DeleteContent implements ContentService.
 func (c *contentService) DeleteContent(ctx context.Context, id int64) error {
-	err = c.contentRepo.DeleteContent(ctx, id)
+	current, err := c.contentRepo.GetContentByID(ctx, id)
 	if err != nil {
 		code = "[SERVICE] DeleteContent - 1"
 		log.Errorw(code, err)
 		return err
 	}
 
+	err = c.contentRepo.DeleteContent(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteContent - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityContent, id, contentAuditSnapshot(*current), nil)
+	c.searchService.RemoveContent(ctx, id)
+
 	return nil
 }
 
+// RecordView implements ContentService. Views only feed the ranking of
+// suggestions, a failed count is logged and otherwise ignored.
+func (c *contentService) RecordView(ctx context.Context, id int64) {
+	err := c.contentRepo.IncrementViewCount(ctx, id)
+	if err != nil {
+		code = "[SERVICE] RecordView - 1"
+		log.Errorw(code, err)
+	}
+}
+
 // GetContentByID implements ContentService.
 func (c *contentService) GetContentByID(ctx context.Context, id int64) (*entity.ContentEntity, error) {
 	result, err := c.contentRepo.GetContentByID(ctx, id)
@@ -61,6 +170,18 @@ func (c *contentService) GetContentByID(ctx context.Context, id int64) (*entity.
 	return result, nil
 }
 
+// GetContentBySlug implements ContentService.
+func (c *contentService) GetContentBySlug(ctx context.Context, slug string) (*entity.ContentEntity, error) {
+	result, err := c.contentRepo.GetContentBySlug(ctx, slug)
+	if err != nil {
+		code = "[SERVICE] GetContentBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetContents implements ContentService.
 func (c *contentService) GetContents(ctx context.Context, query entity.QueryString) ([]entity.ContentEntity, int64, int64, error) {
 	results, totalData, totalPages, err := c.contentRepo.GetContents(ctx, query)
@@ -73,15 +194,82 @@ func (c *contentService) GetContents(ctx context.Context, query entity.QueryStri
 	return results, totalData, totalPages, nil
 }
 
+// GetContentsByCursor implements ContentService.
+func (c *contentService) GetContentsByCursor(ctx context.Context, query entity.QueryString) (*entity.ContentPageEntity, error) {
+	page, err := c.contentRepo.GetContentsByCursor(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetContentsByCursor - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return page, nil
+}
+
 // UpdateContent implements ContentService.
-func (c *contentService) UpdateContent(ctx context.Context, req entity.ContentEntity) error {
-	err = c.contentRepo.UpdateContent(ctx, req)
+// Authors may only edit their own contents, editors and admins may edit any of them.
+func (c *contentService) UpdateContent(ctx context.Context, req entity.ContentEntity, actor entity.UserEntity) error {
+	current, err := c.contentRepo.GetContentByID(ctx, req.ID)
 	if err != nil {
 		code = "[SERVICE] UpdateContent - 1"
 		log.Errorw(code, err)
 		return err
 	}
 
+	if actor.Role == entity.RoleAuthor && current.CreatedByID != actor.ID {
+		code = "[SERVICE] UpdateContent - 2"
+		log.Errorw(code, ErrForbidden)
+		return ErrForbidden
+	}
+
+	if req.KeepPublishAt {
+		req.PublishAt = current.PublishAt
+	}
+	if req.KeepUnpublishAt {
+		req.UnpublishAt = current.UnpublishAt
+	}
+
+	if !validContentSchedule(req) {
+		code = "[SERVICE] UpdateContent - 3"
+		log.Errorw(code, ErrInvalidSchedule)
+		return ErrInvalidSchedule
+	}
+
+	// the scheduler publishes on publish_at, without it the content would
+	// stay scheduled forever
+	if current.Status == entity.ContentStatusScheduled && req.PublishAt == nil {
+		code = "[SERVICE] UpdateContent - 4"
+		log.Errorw(code, ErrScheduledPublishAt)
+		return ErrScheduledPublishAt
+	}
+
+	req.CreatedByID = current.CreatedByID
+	req.EditedByID = actor.ID
+	req.Status = ""
+	if req.Title != current.Title {
+		req.Slug = c.slugifier.Make(req.Title)
+	}
+	if req.Tags != nil {
+		req.Tags = slugTags(c.slugifier, req.Tags)
+	}
+
+	err = c.contentRepo.UpdateContent(ctx, req, backToReview(*current, actor))
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 5"
+		log.Errorw(code, err)
+		return err
+	}
+
+	updated, err := c.contentRepo.GetContentByID(ctx, req.ID)
+	if err != nil {
+		code = "[SERVICE] UpdateContent - 6"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityContent, req.ID, contentAuditSnapshot(*current), contentAuditSnapshot(*updated))
+	c.searchService.IndexContent(ctx, *updated)
+
 	return nil
 }
 
@@ -97,10 +285,275 @@ func (c *contentService) UploadImageR2(ctx context.Context, req entity.FileUploa
 	return urlImage, nil
 }
 
-func NewContentService(repo repository.ContentRepository, cfg *config.Config, r2 cloudflare.CloudflareR2Adapter) ContentService {
+// GetRevisions implements ContentService.
+func (c *contentService) GetRevisions(ctx context.Context, contentID int64, query entity.QueryString) ([]entity.ContentRevisionEntity, int64, int64, error) {
+	_, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetRevisions - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	results, totalData, totalPages, err := c.contentRepo.GetRevisions(ctx, contentID, query)
+	if err != nil {
+		code = "[SERVICE] GetRevisions - 2"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+// GetRevision implements ContentService.
+func (c *contentService) GetRevision(ctx context.Context, contentID int64, revisionNumber int) (*entity.ContentRevisionEntity, error) {
+	result, err := c.contentRepo.GetRevision(ctx, contentID, revisionNumber)
+	if err != nil {
+		code = "[SERVICE] GetRevision - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetRevisionDiff implements ContentService.
+func (c *contentService) GetRevisionDiff(ctx context.Context, contentID int64, from, to int) (*entity.ContentRevisionDiffEntity, error) {
+	fromRevision, err := c.contentRepo.GetRevision(ctx, contentID, from)
+	if err != nil {
+		code = "[SERVICE] GetRevisionDiff - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	toRevision, err := c.contentRepo.GetRevision(ctx, contentID, to)
+	if err != nil {
+		code = "[SERVICE] GetRevisionDiff - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.ContentRevisionDiffEntity{
+		From:    *fromRevision,
+		To:      *toRevision,
+		Changes: diff.Compare(revisionSnapshot(*fromRevision), revisionSnapshot(*toRevision)),
+	}, nil
+}
+
+// RestoreRevision implements ContentService. The same ownership rule as
+// UpdateContent applies, and the restore is stored as a new revision.
+func (c *contentService) RestoreRevision(ctx context.Context, contentID int64, revisionNumber int, actor entity.UserEntity) (int, error) {
+	current, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] RestoreRevision - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	if actor.Role == entity.RoleAuthor && current.CreatedByID != actor.ID {
+		code = "[SERVICE] RestoreRevision - 2"
+		log.Errorw(code, ErrForbidden)
+		return 0, ErrForbidden
+	}
+
+	revision, err := c.contentRepo.GetRevision(ctx, contentID, revisionNumber)
+	if err != nil {
+		code = "[SERVICE] RestoreRevision - 3"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	tags := []entity.TagEntity{}
+	for _, name := range revision.Tags {
+		tags = append(tags, entity.TagEntity{Name: name})
+	}
+
+	newRevision, err := c.contentRepo.RestoreRevision(ctx, contentID, revisionNumber, actor.ID, slugTags(c.slugifier, tags), backToReview(*current, actor))
+	if err != nil {
+		code = "[SERVICE] RestoreRevision - 4"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	updated, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] RestoreRevision - 5"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityContent, contentID, contentAuditSnapshot(*current), contentAuditSnapshot(*updated))
+	c.searchService.IndexContent(ctx, *updated)
+
+	return newRevision, nil
+}
+
+// TransitionContent implements ContentService. Authors may only submit their own
+// contents, every other step is decided by the roles in contentTransitions.
+func (c *contentService) TransitionContent(ctx context.Context, contentID int64, action, comment string, actor entity.UserEntity) (*entity.ContentEntity, error) {
+	transition, ok := contentTransitions[action]
+	if !ok {
+		code = "[SERVICE] TransitionContent - 1"
+		log.Errorw(code, ErrInvalidTransition)
+		return nil, ErrInvalidTransition
+	}
+
+	if !slices.Contains(transition.Roles, actor.Role) {
+		code = "[SERVICE] TransitionContent - 2"
+		log.Errorw(code, ErrForbidden)
+		return nil, ErrForbidden
+	}
+
+	comment = strings.TrimSpace(comment)
+	if transition.CommentNeeded && comment == "" {
+		code = "[SERVICE] TransitionContent - 3"
+		log.Errorw(code, ErrReviewCommentNeeded)
+		return nil, ErrReviewCommentNeeded
+	}
+
+	current, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] TransitionContent - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if actor.Role == entity.RoleAuthor && current.CreatedByID != actor.ID {
+		code = "[SERVICE] TransitionContent - 5"
+		log.Errorw(code, ErrForbidden)
+		return nil, ErrForbidden
+	}
+
+	if !slices.Contains(transition.From, current.Status) {
+		code = "[SERVICE] TransitionContent - 6"
+		log.Errorw(code, ErrInvalidTransition)
+		return nil, ErrInvalidTransition
+	}
+
+	if action == entity.ContentActionSchedule && current.PublishAt == nil {
+		code = "[SERVICE] TransitionContent - 7"
+		log.Errorw(code, ErrPublishAtRequired)
+		return nil, ErrPublishAtRequired
+	}
+
+	err = c.contentRepo.TransitionContent(ctx, entity.ContentReviewEntity{
+		ContentID:  contentID,
+		Action:     action,
+		FromStatus: current.Status,
+		ToStatus:   transition.To,
+		Comment:    comment,
+		ReviewerID: actor.ID,
+	})
+	if err != nil {
+		code = "[SERVICE] TransitionContent - 8"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	updated, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] TransitionContent - 9"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityContent, contentID, contentAuditSnapshot(*current), contentAuditSnapshot(*updated))
+	c.searchService.IndexContent(ctx, *updated)
+
+	return updated, nil
+}
+
+// backToReview returns the transition that moves a reviewed content back to
+// IN_REVIEW when an author changes it, see reviewedStatuses. The repository
+// applies it in the same transaction as the change.
+func backToReview(current entity.ContentEntity, actor entity.UserEntity) *entity.ContentReviewEntity {
+	if actor.Role != entity.RoleAuthor || !slices.Contains(reviewedStatuses, current.Status) {
+		return nil
+	}
+
+	return &entity.ContentReviewEntity{
+		ContentID:  current.ID,
+		Action:     entity.ContentActionEdit,
+		FromStatus: current.Status,
+		ToStatus:   entity.ContentStatusInReview,
+		ReviewerID: actor.ID,
+	}
+}
+
+// GetContentReviews implements ContentService.
+func (c *contentService) GetContentReviews(ctx context.Context, contentID int64) ([]entity.ContentReviewEntity, error) {
+	_, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetContentReviews - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	results, err := c.contentRepo.GetContentReviews(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetContentReviews - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func validContentSchedule(content entity.ContentEntity) bool {
+	if content.PublishAt == nil || content.UnpublishAt == nil {
+		return true
+	}
+
+	return content.UnpublishAt.After(*content.PublishAt)
+}
+
+func revisionSnapshot(revision entity.ContentRevisionEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"title":       revision.Title,
+		"excerpt":     revision.Excerpt,
+		"description": revision.Description,
+		"image":       revision.Image,
+		"tags":        revision.Tags,
+		"sources":     contentSourceUrls(revision.Sources),
+		"status":      revision.Status,
+		"is_valid":    revision.IsValid,
+		"category_id": revision.CategoryID,
+	}
+}
+
+func contentAuditSnapshot(content entity.ContentEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"title":         content.Title,
+		"slug":          content.Slug,
+		"excerpt":       content.Excerpt,
+		"description":   content.Description,
+		"image":         content.Image,
+		"tags":          entity.TagNames(content.Tags),
+		"status":        content.Status,
+		"is_valid":      content.IsValid,
+		"category_id":   content.CategoryID,
+		"created_by_id": content.CreatedByID,
+		"publish_at":    content.PublishAt,
+		"unpublish_at":  content.UnpublishAt,
+		"sources":       contentSourceUrls(content.Sources),
+	}
+}
+
+func contentSourceUrls(sources []entity.ContentSourceEntity) []string {
+	urls := []string{}
+	for _, source := range sources {
+		urls = append(urls, source.Url)
+	}
+
+	return urls
+}
+
+func NewContentService(repo repository.ContentRepository, cfg *config.Config, r2 cloudflare.CloudflareR2Adapter, auditService AuditService, slugifier *slug.Slugifier, searchService SearchService) ContentService {
 	return &contentService{
 		contentRepo: repo,
 		cfg:         cfg,
 		r2:          r2,
+		auditService: auditService,
+		slugifier:   slugifier,
+		searchService: searchService,
 	}
 }
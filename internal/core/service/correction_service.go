@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"time"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type CorrectionService interface {
+	GetCorrections(ctx context.Context, contentID int64) ([]entity.ContentCorrectionEntity, error)
+	CreateCorrection(ctx context.Context, req entity.ContentCorrectionEntity) (*entity.ContentCorrectionEntity, error)
+	DeleteCorrection(ctx context.Context, contentID, id int64) error
+
+	// FE
+	GetPublishedCorrections(ctx context.Context, query entity.QueryString) ([]entity.ContentCorrectionEntity, int64, int64, error)
+}
+
+type correctionService struct {
+	correctionRepo repository.ContentCorrectionRepository
+	contentRepo    repository.ContentRepository
+	auditService   AuditService
+}
+
+// GetCorrections implements CorrectionService.
+func (c *correctionService) GetCorrections(ctx context.Context, contentID int64) ([]entity.ContentCorrectionEntity, error) {
+	_, err := c.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetCorrections - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	results, err := c.correctionRepo.GetCorrectionsByContentID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetCorrections - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CreateCorrection implements CorrectionService. The correction date defaults
+// to now.
+func (c *correctionService) CreateCorrection(ctx context.Context, req entity.ContentCorrectionEntity) (*entity.ContentCorrectionEntity, error) {
+	_, err := c.contentRepo.GetContentByID(ctx, req.ContentID)
+	if err != nil {
+		code = "[SERVICE] CreateCorrection - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if req.CorrectedAt.IsZero() {
+		req.CorrectedAt = time.Now()
+	}
+
+	id, err := c.correctionRepo.CreateCorrection(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateCorrection - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	created, err := c.correctionRepo.GetCorrectionByID(ctx, req.ContentID, id)
+	if err != nil {
+		code = "[SERVICE] CreateCorrection - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionCreate, entity.AuditEntityCorrection, id, nil, correctionAuditSnapshot(*created))
+
+	return created, nil
+}
+
+// DeleteCorrection implements CorrectionService.
+func (c *correctionService) DeleteCorrection(ctx context.Context, contentID, id int64) error {
+	current, err := c.correctionRepo.GetCorrectionByID(ctx, contentID, id)
+	if err != nil {
+		code = "[SERVICE] DeleteCorrection - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = c.correctionRepo.DeleteCorrection(ctx, contentID, id)
+	if err != nil {
+		code = "[SERVICE] DeleteCorrection - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	c.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityCorrection, id, correctionAuditSnapshot(*current), nil)
+
+	return nil
+}
+
+// GetPublishedCorrections implements CorrectionService.
+func (c *correctionService) GetPublishedCorrections(ctx context.Context, query entity.QueryString) ([]entity.ContentCorrectionEntity, int64, int64, error) {
+	results, totalData, totalPages, err := c.correctionRepo.GetPublishedCorrections(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetPublishedCorrections - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+func correctionAuditSnapshot(correction entity.ContentCorrectionEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"content_id":     correction.ContentID,
+		"corrected_at":   correction.CorrectedAt,
+		"what_was_wrong": correction.WhatWasWrong,
+		"what_changed":   correction.WhatChanged,
+		"severity":       correction.Severity,
+	}
+}
+
+func NewCorrectionService(correctionRepo repository.ContentCorrectionRepository, contentRepo repository.ContentRepository, auditService AuditService) CorrectionService {
+	return &correctionService{
+		correctionRepo: correctionRepo,
+		contentRepo:    contentRepo,
+		auditService:   auditService,
+	}
+}
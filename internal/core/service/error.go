@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"time"
+	"trustnews/internal/adapter/repository"
+)
+
+var (
+	ErrForbidden           = errors.New("You Do Not Have Permission To Perform This Action")
+	ErrInvalidRefreshToken = errors.New("Invalid Refresh Token")
+	ErrRefreshTokenReused  = errors.New("Refresh Token Has Already Been Used")
+	ErrUserInactive        = errors.New("User Account Is Deactivated")
+	ErrSelfDeactivation    = errors.New("You Cannot Deactivate Your Own Account")
+	ErrEmailAlreadyUsed    = repository.ErrEmailAlreadyUsed
+	ErrInvalidResetToken   = errors.New("Invalid Or Expired Reset Token")
+	ErrInvalidMfaToken     = errors.New("Invalid Or Expired Two-Factor Session")
+	ErrInvalidMfaCode      = errors.New("Invalid Two-Factor Code")
+	ErrMfaAlreadyEnabled   = errors.New("Two-Factor Authentication Is Already Enabled")
+	ErrMfaNotEnrolled      = errors.New("Two-Factor Authentication Is Not Enrolled")
+	ErrMfaRequiredForRole  = errors.New("Two-Factor Authentication Is Required For Your Role")
+	ErrInvalidCredentials  = errors.New("Invalid Credentials")
+	ErrLoginLocked         = errors.New("Too Many Failed Login Attempts, Try Again Later")
+	ErrInvalidApiKeyOwner  = errors.New("API Key Owner Must Be An Active User")
+	ErrScopeNotAllowed     = errors.New("The Key Owner's Role Cannot Use One Of The Scopes")
+	ErrInvalidTransition   = errors.New("This Action Is Not Allowed From The Current Status")
+	ErrReviewCommentNeeded = errors.New("A Reviewer Comment Is Required For This Action")
+	ErrStaleContentStatus  = repository.ErrContentStatusChanged
+	ErrInvalidSchedule     = errors.New("Unpublish Time Must Be After Publish Time")
+	ErrPublishAtRequired   = errors.New("Set A Publish Time Before Scheduling")
+	ErrScheduledPublishAt  = errors.New("A Scheduled Content Needs Its Publish Time")
+	ErrInvalidReportStatus = errors.New("The Report Cannot Move To This Status")
+	ErrStaleReportStatus   = repository.ErrReportStatusChanged
+	ErrTagNameTaken        = repository.ErrTagSlugTaken
+	ErrInvalidTagName      = errors.New("The Tag Name Needs At Least One Letter Or Digit")
+	ErrMergeSameTag        = errors.New("A Tag Cannot Be Merged Into Itself")
+	ErrCategoryCycle       = repository.ErrCategoryCycle
+	ErrParentNotFound      = errors.New("Parent Category Not Found")
+)
+
+// LoginLockedError carries how long the caller has to wait, it matches
+// ErrLoginLocked with errors.Is.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
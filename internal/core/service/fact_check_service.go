@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+type FactCheckService interface {
+	GetFactCheck(ctx context.Context, contentID int64) (*entity.FactCheckEntity, error)
+	SaveFactCheck(ctx context.Context, req entity.FactCheckEntity, actor entity.UserEntity) (*entity.FactCheckEntity, error)
+	DeleteFactCheck(ctx context.Context, contentID int64) error
+
+	// FE
+	GetClaimReview(ctx context.Context, contentID int64) (*entity.ClaimReviewEntity, error)
+}
+
+type factCheckService struct {
+	factCheckRepo repository.FactCheckRepository
+	contentRepo   repository.ContentRepository
+	auditService  AuditService
+	searchService SearchService
+	cfg           *config.Config
+}
+
+// GetFactCheck implements FactCheckService.
+func (f *factCheckService) GetFactCheck(ctx context.Context, contentID int64) (*entity.FactCheckEntity, error) {
+	result, err := f.factCheckRepo.GetFactCheckByContentID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetFactCheck - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveFactCheck implements FactCheckService. The acting user is recorded as the
+// reviewer, the review date defaults to now.
+func (f *factCheckService) SaveFactCheck(ctx context.Context, req entity.FactCheckEntity, actor entity.UserEntity) (*entity.FactCheckEntity, error) {
+	_, err := f.contentRepo.GetContentByID(ctx, req.ContentID)
+	if err != nil {
+		code = "[SERVICE] SaveFactCheck - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	var before map[string]interface{}
+	current, err := f.factCheckRepo.GetFactCheckByContentID(ctx, req.ContentID)
+	if err == nil {
+		before = factCheckAuditSnapshot(*current)
+	}
+
+	req.ReviewerID = actor.ID
+	if req.ReviewedAt.IsZero() {
+		req.ReviewedAt = time.Now()
+	}
+
+	err = f.factCheckRepo.SaveFactCheck(ctx, req)
+	if err != nil {
+		code = "[SERVICE] SaveFactCheck - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	saved, err := f.factCheckRepo.GetFactCheckByContentID(ctx, req.ContentID)
+	if err != nil {
+		code = "[SERVICE] SaveFactCheck - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	action := entity.AuditActionUpdate
+	if before == nil {
+		action = entity.AuditActionCreate
+	}
+	f.auditService.Record(ctx, action, entity.AuditEntityFactCheck, req.ContentID, before, factCheckAuditSnapshot(*saved))
+	f.searchService.IndexContents(ctx, entity.QueryString{IDs: []int64{req.ContentID}})
+
+	return saved, nil
+}
+
+// DeleteFactCheck implements FactCheckService.
+func (f *factCheckService) DeleteFactCheck(ctx context.Context, contentID int64) error {
+	current, err := f.factCheckRepo.GetFactCheckByContentID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] DeleteFactCheck - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = f.factCheckRepo.DeleteFactCheck(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] DeleteFactCheck - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	f.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityFactCheck, contentID, factCheckAuditSnapshot(*current), nil)
+	f.searchService.IndexContents(ctx, entity.QueryString{IDs: []int64{contentID}})
+
+	return nil
+}
+
+// GetClaimReview implements FactCheckService. Only published contents with a
+// fact check have a ClaimReview.
+func (f *factCheckService) GetClaimReview(ctx context.Context, contentID int64) (*entity.ClaimReviewEntity, error) {
+	content, err := f.contentRepo.GetContentByID(ctx, contentID)
+	if err != nil {
+		code = "[SERVICE] GetClaimReview - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if content.Status != entity.ContentStatusPublished || content.FactCheck == nil {
+		code = "[SERVICE] GetClaimReview - 2"
+		log.Errorw(code, gorm.ErrRecordNotFound)
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	// the article is linked by its slug, the url the reader sees
+	siteUrl := strings.TrimRight(f.cfg.App.FrontendUrl, "/")
+	return &entity.ClaimReviewEntity{
+		Url:       fmt.Sprintf("%s/contents/%s", siteUrl, url.PathEscape(content.Slug)),
+		SiteName:  f.cfg.App.SiteName,
+		SiteUrl:   siteUrl,
+		Title:     content.Title,
+		FactCheck: *content.FactCheck,
+	}, nil
+}
+
+func factCheckAuditSnapshot(factCheck entity.FactCheckEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"claim":          factCheck.Claim,
+		"claimant":       factCheck.Claimant,
+		"rating":         factCheck.Rating,
+		"reviewer_id":    factCheck.ReviewerID,
+		"reviewed_at":    factCheck.ReviewedAt,
+		"evidence_links": factCheck.EvidenceLinks,
+	}
+}
+
+func NewFactCheckService(factCheckRepo repository.FactCheckRepository, contentRepo repository.ContentRepository, auditService AuditService, searchService SearchService, cfg *config.Config) FactCheckService {
+	return &factCheckService{
+		factCheckRepo: factCheckRepo,
+		contentRepo:   contentRepo,
+		auditService:  auditService,
+		searchService: searchService,
+		cfg:           cfg,
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type LockoutService interface {
+	GetLockoutEvents(ctx context.Context, query entity.QueryString, scope string) ([]entity.LockoutEventEntity, int64, int64, error)
+}
+
+type lockoutService struct {
+	loginThrottleRepo repository.LoginThrottleRepository
+}
+
+// GetLockoutEvents implements LockoutService.
+func (l *lockoutService) GetLockoutEvents(ctx context.Context, query entity.QueryString, scope string) ([]entity.LockoutEventEntity, int64, int64, error) {
+	results, totalData, totalPages, err := l.loginThrottleRepo.GetLockoutEvents(ctx, query, scope)
+	if err != nil {
+		code = "[SERVICE] GetLockoutEvents - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+func NewLockoutService(loginThrottleRepo repository.LoginThrottleRepository) LockoutService {
+	return &lockoutService{
+		loginThrottleRepo: loginThrottleRepo,
+	}
+}
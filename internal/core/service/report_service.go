@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+// reportTransitions lists where a report may go from each open status, upheld
+// and rejected reports are closed.
+var reportTransitions = map[string][]string{
+	entity.ReportStatusNew:     {entity.ReportStatusTriaged, entity.ReportStatusUpheld, entity.ReportStatusRejected},
+	entity.ReportStatusTriaged: {entity.ReportStatusUpheld, entity.ReportStatusRejected},
+}
+
+type ReportService interface {
+	GetReports(ctx context.Context, query entity.ContentReportQuery) ([]entity.ContentReportEntity, int64, int64, error)
+	GetReportByID(ctx context.Context, id int64) (*entity.ContentReportEntity, error)
+	ModerateReport(ctx context.Context, id int64, status, note string, actor entity.UserEntity) (*entity.ContentReportEntity, error)
+
+	// FE
+	CreateReport(ctx context.Context, req entity.ContentReportEntity) error
+}
+
+type reportService struct {
+	reportRepo    repository.ContentReportRepository
+	contentRepo   repository.ContentRepository
+	auditService  AuditService
+	searchService SearchService
+}
+
+// CreateReport implements ReportService. Only published contents can be
+// reported, the reporter's IP and user agent are kept to spot abuse.
+func (r *reportService) CreateReport(ctx context.Context, req entity.ContentReportEntity) error {
+	content, err := r.contentRepo.GetContentByID(ctx, req.ContentID)
+	if err != nil {
+		code = "[SERVICE] CreateReport - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if content.Status != entity.ContentStatusPublished {
+		code = "[SERVICE] CreateReport - 2"
+		log.Errorw(code, gorm.ErrRecordNotFound)
+		return gorm.ErrRecordNotFound
+	}
+
+	if meta, ok := ctx.Value(entity.RequestMetaKey).(entity.RequestMeta); ok {
+		req.IPAddress = meta.IPAddress
+		req.UserAgent = meta.UserAgent
+	}
+
+	_, err = r.reportRepo.CreateReport(ctx, req)
+	if err != nil {
+		code = "[SERVICE] CreateReport - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetReports implements ReportService.
+func (r *reportService) GetReports(ctx context.Context, query entity.ContentReportQuery) ([]entity.ContentReportEntity, int64, int64, error) {
+	results, totalData, totalPages, err := r.reportRepo.GetReports(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetReports - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+// GetReportByID implements ReportService.
+func (r *reportService) GetReportByID(ctx context.Context, id int64) (*entity.ContentReportEntity, error) {
+	result, err := r.reportRepo.GetReportByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] GetReportByID - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ModerateReport implements ReportService. Upholding a report flags the
+// content as NEEDS_REVIEW so editors pick it up again.
+func (r *reportService) ModerateReport(ctx context.Context, id int64, status, note string, actor entity.UserEntity) (*entity.ContentReportEntity, error) {
+	current, err := r.reportRepo.GetReportByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] ModerateReport - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if !slices.Contains(reportTransitions[current.Status], status) {
+		code = "[SERVICE] ModerateReport - 2"
+		log.Errorw(code, ErrInvalidReportStatus)
+		return nil, ErrInvalidReportStatus
+	}
+
+	var contentBefore *entity.ContentEntity
+	flagContent := status == entity.ReportStatusUpheld
+	if flagContent {
+		contentBefore, err = r.contentRepo.GetContentByID(ctx, current.ContentID)
+		if err != nil {
+			code = "[SERVICE] ModerateReport - 3"
+			log.Errorw(code, err)
+			return nil, err
+		}
+	}
+
+	req := *current
+	req.Status = status
+	req.ModeratorNote = note
+	req.ModeratorID = actor.ID
+	if status == entity.ReportStatusUpheld || status == entity.ReportStatusRejected {
+		now := time.Now()
+		req.ResolvedAt = &now
+	}
+
+	err = r.reportRepo.ModerateReport(ctx, req, current.Status, flagContent)
+	if err != nil {
+		code = "[SERVICE] ModerateReport - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	updated, err := r.reportRepo.GetReportByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] ModerateReport - 5"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	r.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityReport, id, reportAuditSnapshot(*current), reportAuditSnapshot(*updated))
+
+	if flagContent {
+		contentAfter, err := r.contentRepo.GetContentByID(ctx, current.ContentID)
+		if err == nil {
+			r.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityContent, current.ContentID, contentAuditSnapshot(*contentBefore), contentAuditSnapshot(*contentAfter))
+			r.searchService.IndexContent(ctx, *contentAfter)
+		}
+	}
+
+	return updated, nil
+}
+
+func reportAuditSnapshot(report entity.ContentReportEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"status":         report.Status,
+		"moderator_id":   report.ModeratorID,
+		"moderator_note": report.ModeratorNote,
+	}
+}
+
+func NewReportService(reportRepo repository.ContentReportRepository, contentRepo repository.ContentRepository, auditService AuditService, searchService SearchService) ReportService {
+	return &reportService{
+		reportRepo:    reportRepo,
+		contentRepo:   contentRepo,
+		auditService:  auditService,
+		searchService: searchService,
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"trustnews/internal/core/domain/entity"
+)
+
+// SearchService is the port for content search. The implementations live in
+// internal/adapter/search, SEARCH_DRIVER picks one of them.
+//
+// Index updates are best effort: a failure is logged and never fails the
+// write that caused it, the index can always be rebuilt with Reindex.
+type SearchService interface {
+	Search(ctx context.Context, query entity.SearchQueryEntity) (*entity.SearchResultEntity, error)
+	IndexContent(ctx context.Context, content entity.ContentEntity)
+	RemoveContent(ctx context.Context, id int64)
+	// IndexContents indexes the contents matching query again, after a change
+	// to something their documents copy, like a tag name or a fact check.
+	IndexContents(ctx context.Context, query entity.QueryString)
+	// Reindex rebuilds the index from the database and returns the number of
+	// indexed contents.
+	Reindex(ctx context.Context) (int, error)
+	Close() error
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// minSuggestionLength is the shortest query worth looking up, a single letter
+// matches half of the titles.
+const minSuggestionLength = 2
+
+type SuggestionService interface {
+	GetSuggestions(ctx context.Context, text string, limit int) (*entity.SuggestionEntity, error)
+}
+
+type suggestionService struct {
+	suggestionRepo repository.SuggestionRepository
+}
+
+// GetSuggestions implements SuggestionService. limit applies to each kind of
+// suggestion on its own.
+func (s *suggestionService) GetSuggestions(ctx context.Context, text string, limit int) (*entity.SuggestionEntity, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	if len([]rune(text)) < minSuggestionLength {
+		return &entity.SuggestionEntity{
+			Contents:   []entity.ContentEntity{},
+			Categories: []entity.CategoryEntity{},
+			Tags:       []entity.TagEntity{},
+		}, nil
+	}
+
+	result, err := s.suggestionRepo.GetSuggestions(ctx, text, limit)
+	if err != nil {
+		code = "[SERVICE] GetSuggestions - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func NewSuggestionService(suggestionRepo repository.SuggestionRepository) SuggestionService {
+	return &suggestionService{
+		suggestionRepo: suggestionRepo,
+	}
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/lib/slug"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// maxTagNameLength matches tags.name.
+const maxTagNameLength = 100
+
+type TagService interface {
+	GetTags(ctx context.Context, query entity.QueryString) ([]entity.TagEntity, int64, int64, error)
+	GetTagBySlug(ctx context.Context, slug string) (*entity.TagEntity, error)
+	RenameTag(ctx context.Context, id int64, name string, actor entity.UserEntity) (*entity.TagEntity, error)
+	MergeTags(ctx context.Context, sourceID, targetID int64, actor entity.UserEntity) (*entity.TagEntity, error)
+	DeleteTag(ctx context.Context, id int64, actor entity.UserEntity) error
+	SlugTags(ctx context.Context) (int, int, int, error)
+	SlugLegacyTags(ctx context.Context) error
+}
+
+type tagService struct {
+	tagRepo       repository.TagRepository
+	contentRepo   repository.ContentRepository
+	auditService  AuditService
+	slugifier     *slug.Slugifier
+	searchService SearchService
+}
+
+// GetTags implements TagService.
+func (t *tagService) GetTags(ctx context.Context, query entity.QueryString) ([]entity.TagEntity, int64, int64, error) {
+	results, totalData, totalPages, err := t.tagRepo.GetTags(ctx, query)
+	if err != nil {
+		code = "[SERVICE] GetTags - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+// GetTagBySlug implements TagService.
+func (t *tagService) GetTagBySlug(ctx context.Context, slug string) (*entity.TagEntity, error) {
+	result, err := t.tagRepo.GetTagBySlug(ctx, slug)
+	if err != nil {
+		code = "[SERVICE] GetTagBySlug - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RenameTag implements TagService. The slug follows the new name.
+func (t *tagService) RenameTag(ctx context.Context, id int64, name string, actor entity.UserEntity) (*entity.TagEntity, error) {
+	current, err := t.tagRepo.GetTagByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] RenameTag - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	req := entity.TagEntity{
+		ID:   id,
+		Name: strings.TrimSpace(name),
+		Slug: t.slugifier.Make(name),
+	}
+	if req.Slug == "" {
+		code = "[SERVICE] RenameTag - 2"
+		log.Errorw(code, ErrInvalidTagName)
+		return nil, ErrInvalidTagName
+	}
+
+	err = t.tagRepo.RenameTag(ctx, req, actor.ID)
+	if err != nil {
+		code = "[SERVICE] RenameTag - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	updated, err := t.tagRepo.GetTagByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] RenameTag - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	t.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityTag, id, tagAuditSnapshot(*current), tagAuditSnapshot(*updated))
+	t.searchService.IndexContents(ctx, entity.QueryString{TagID: id})
+
+	return updated, nil
+}
+
+// MergeTags implements TagService. The source tag is deleted, its contents
+// carry the target tag afterwards.
+func (t *tagService) MergeTags(ctx context.Context, sourceID, targetID int64, actor entity.UserEntity) (*entity.TagEntity, error) {
+	if sourceID == targetID {
+		code = "[SERVICE] MergeTags - 1"
+		log.Errorw(code, ErrMergeSameTag)
+		return nil, ErrMergeSameTag
+	}
+
+	source, err := t.tagRepo.GetTagByID(ctx, sourceID)
+	if err != nil {
+		code = "[SERVICE] MergeTags - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	target, err := t.tagRepo.GetTagByID(ctx, targetID)
+	if err != nil {
+		code = "[SERVICE] MergeTags - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	err = t.tagRepo.MergeTags(ctx, sourceID, targetID, actor.ID)
+	if err != nil {
+		code = "[SERVICE] MergeTags - 4"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	updated, err := t.tagRepo.GetTagByID(ctx, targetID)
+	if err != nil {
+		code = "[SERVICE] MergeTags - 5"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	t.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityTag, sourceID, tagAuditSnapshot(*source), nil)
+	t.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityTag, targetID, tagAuditSnapshot(*target), tagAuditSnapshot(*updated))
+	t.searchService.IndexContents(ctx, entity.QueryString{TagID: targetID})
+
+	return updated, nil
+}
+
+// DeleteTag implements TagService.
+func (t *tagService) DeleteTag(ctx context.Context, id int64, actor entity.UserEntity) error {
+	current, err := t.tagRepo.GetTagByID(ctx, id)
+	if err != nil {
+		code = "[SERVICE] DeleteTag - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	// the contents lose the tag, they have to be found before
+	tagged, err := t.contentRepo.GetContentIDs(ctx, entity.QueryString{TagID: id})
+	if err != nil {
+		code = "[SERVICE] DeleteTag - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = t.tagRepo.DeleteTag(ctx, id, actor.ID)
+	if err != nil {
+		code = "[SERVICE] DeleteTag - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	t.auditService.Record(ctx, entity.AuditActionDelete, entity.AuditEntityTag, id, tagAuditSnapshot(*current), nil)
+	t.searchService.IndexContents(ctx, entity.QueryString{IDs: tagged})
+
+	return nil
+}
+
+// SlugTags implements TagService. Every tag gets the slug the application makes
+// of its name. Tags ending up with the same slug are merged into the most used
+// one, tags whose name leaves no slug get a "tag-N" fallback. It returns the
+// number of tags, of merged tags and of tags without a slug of their own.
+func (t *tagService) SlugTags(ctx context.Context) (int, int, int, error) {
+	// all tags are read first, merging shifts the pages
+	var tags []entity.TagEntity
+	for page, totalPages := int64(1), int64(1); page <= totalPages; page++ {
+		var batch []entity.TagEntity
+		batch, _, totalPages, err = t.tagRepo.GetTags(ctx, entity.QueryString{Limit: 200, Page: int(page)})
+		if err != nil {
+			code = "[SERVICE] SlugTags - 1"
+			log.Errorw(code, err)
+			return 0, 0, 0, err
+		}
+		tags = append(tags, batch...)
+	}
+
+	// tags with a slug go first so a fallback never takes a slug one of them needs
+	var fallbacks []entity.TagEntity
+	merged := 0
+	for _, tag := range tags {
+		tag.Slug = t.slugifier.Make(tag.Name)
+		if tag.Slug == "" {
+			fallbacks = append(fallbacks, tag)
+			continue
+		}
+
+		targetID, err := t.tagRepo.SlugTag(ctx, tag.ID, tag.Slug)
+		if err != nil {
+			code = "[SERVICE] SlugTags - 2"
+			log.Errorw(code, err)
+			return 0, 0, 0, err
+		}
+		if targetID != tag.ID {
+			merged++
+		}
+	}
+
+	for _, tag := range fallbacks {
+		_, err = t.tagRepo.SlugTag(ctx, tag.ID, "")
+		if err != nil {
+			code = "[SERVICE] SlugTags - 3"
+			log.Errorw(code, err)
+			return 0, 0, 0, err
+		}
+	}
+
+	return len(tags), merged, len(fallbacks), nil
+}
+
+// SlugLegacyTags implements TagService. It runs SlugTags and rebuilds the search
+// index when tags still hold the placeholder slug of the tags migration, their
+// tag pages are not found until then.
+func (t *tagService) SlugLegacyTags(ctx context.Context) error {
+	legacy, err := t.tagRepo.CountLegacyTags(ctx)
+	if err != nil {
+		code = "[SERVICE] SlugLegacyTags - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if legacy == 0 {
+		return nil
+	}
+
+	total, merged, fallbacks, err := t.SlugTags(ctx)
+	if err != nil {
+		code = "[SERVICE] SlugLegacyTags - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	_, err = t.searchService.Reindex(ctx)
+	if err != nil {
+		code = "[SERVICE] SlugLegacyTags - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	log.Infow("[SERVICE] legacy tags slugged", "legacy", legacy, "tags", total, "merged", merged, "fallbacks", fallbacks)
+
+	return nil
+}
+
+// slugTags fills in the slug of each tag and drops tags without one as well as
+// repeated ones, "Politik" and "politik" are the same tag. Names are cut to the
+// size of the column.
+func slugTags(slugifier *slug.Slugifier, tags []entity.TagEntity) []entity.TagEntity {
+	seen := map[string]bool{}
+	resps := []entity.TagEntity{}
+	for _, tag := range tags {
+		tag.Name = strings.TrimSpace(tag.Name)
+		if name := []rune(tag.Name); len(name) > maxTagNameLength {
+			tag.Name = strings.TrimSpace(string(name[:maxTagNameLength]))
+		}
+		tag.Slug = slugifier.Make(tag.Name)
+		if tag.Slug == "" || seen[tag.Slug] {
+			continue
+		}
+
+		seen[tag.Slug] = true
+		resps = append(resps, tag)
+	}
+
+	return resps
+}
+
+func tagAuditSnapshot(tag entity.TagEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        tag.Name,
+		"slug":        tag.Slug,
+		"usage_count": tag.UsageCount,
+	}
+}
+
+func NewTagService(tagRepo repository.TagRepository, contentRepo repository.ContentRepository, auditService AuditService, slugifier *slug.Slugifier, searchService SearchService) TagService {
+	return &tagService{
+		tagRepo:       tagRepo,
+		contentRepo:   contentRepo,
+		auditService:  auditService,
+		slugifier:     slugifier,
+		searchService: searchService,
+	}
+}
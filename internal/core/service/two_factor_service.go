@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"trustnews/config"
+	"trustnews/internal/adapter/repository"
+	"trustnews/internal/core/domain/entity"
+	"trustnews/lib/conv"
+	"trustnews/lib/totp"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const recoveryCodeCount = 10
+
+type TwoFactorService interface {
+	Enroll(ctx context.Context, userID int64) (*entity.TwoFactorEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID int64, code string) error
+	Disable(ctx context.Context, userID int64, code string) error
+
+	GetRequiredRoles(ctx context.Context) ([]string, error)
+	SetRequiredRoles(ctx context.Context, roles []string) error
+}
+
+type twoFactorService struct {
+	twoFactorRepo repository.TwoFactorRepository
+	userRepo      repository.UserRepository
+	cfg           *config.Config
+}
+
+// Enroll implements TwoFactorService. It generates a fresh secret and recovery
+// codes, the secret only becomes active after ConfirmEnrollment.
+func (t *twoFactorService) Enroll(ctx context.Context, userID int64) (*entity.TwoFactorEnrollment, error) {
+	user, err := t.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		code = "[SERVICE] Enroll - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	current, err := t.twoFactorRepo.GetTwoFactor(ctx, user.ID)
+	if err != nil {
+		code = "[SERVICE] Enroll - 2"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	if current.Enabled {
+		return nil, ErrMfaAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		code = "[SERVICE] Enroll - 3"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	recoveryCodes := []string{}
+	recoveryHashes := []string{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		recoveryCode, err := generateRecoveryCode()
+		if err != nil {
+			code = "[SERVICE] Enroll - 4"
+			log.Errorw(code, err)
+			return nil, err
+		}
+
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		recoveryHashes = append(recoveryHashes, hashRecoveryCode(recoveryCode))
+	}
+
+	err = t.twoFactorRepo.SaveTwoFactorSecret(ctx, user.ID, secret, recoveryHashes)
+	if err != nil {
+		code = "[SERVICE] Enroll - 5"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return &entity.TwoFactorEnrollment{
+		Secret:        secret,
+		OtpauthUri:    totp.KeyURI(t.cfg.App.TotpIssuer, user.Email, secret),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// ConfirmEnrollment implements TwoFactorService.
+func (t *twoFactorService) ConfirmEnrollment(ctx context.Context, userID int64, otp string) error {
+	current, err := t.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		code = "[SERVICE] ConfirmEnrollment - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if current.Enabled {
+		return ErrMfaAlreadyEnabled
+	}
+
+	if current.Secret == "" {
+		return ErrMfaNotEnrolled
+	}
+
+	step, ok := totp.Validate(current.Secret, otp, time.Now())
+	if !ok {
+		return ErrInvalidMfaCode
+	}
+
+	err = t.twoFactorRepo.EnableTwoFactor(ctx, userID, step)
+	if err != nil {
+		code = "[SERVICE] ConfirmEnrollment - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// Disable implements TwoFactorService. Users whose role requires 2FA cannot
+// turn it off themselves.
+func (t *twoFactorService) Disable(ctx context.Context, userID int64, otp string) error {
+	user, err := t.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		code = "[SERVICE] Disable - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	required, err := t.twoFactorRepo.IsTwoFactorRequired(ctx, user.Role)
+	if err != nil {
+		code = "[SERVICE] Disable - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if required {
+		return ErrMfaRequiredForRole
+	}
+
+	valid, err := verifyTwoFactorCode(ctx, t.twoFactorRepo, user.ID, otp)
+	if err != nil {
+		code = "[SERVICE] Disable - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	if !valid {
+		return ErrInvalidMfaCode
+	}
+
+	err = t.twoFactorRepo.DisableTwoFactor(ctx, user.ID)
+	if err != nil {
+		code = "[SERVICE] Disable - 4"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRequiredRoles implements TwoFactorService.
+func (t *twoFactorService) GetRequiredRoles(ctx context.Context) ([]string, error) {
+	roles, err := t.twoFactorRepo.GetRequiredRoles(ctx)
+	if err != nil {
+		code = "[SERVICE] GetRequiredRoles - 1"
+		log.Errorw(code, err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// SetRequiredRoles implements TwoFactorService.
+func (t *twoFactorService) SetRequiredRoles(ctx context.Context, roles []string) error {
+	roles = slices.Compact(slices.Sorted(slices.Values(roles)))
+	err = t.twoFactorRepo.SetRequiredRoles(ctx, roles)
+	if err != nil {
+		code = "[SERVICE] SetRequiredRoles - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	return nil
+}
+
+// verifyTwoFactorCode accepts either a TOTP code or one of the unused
+// recovery codes of an enrolled user.
+func verifyTwoFactorCode(ctx context.Context, repo repository.TwoFactorRepository, userID int64, otp string) (bool, error) {
+	current, err := repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if !current.Enabled {
+		return false, ErrMfaNotEnrolled
+	}
+
+	if step, ok := totp.Validate(current.Secret, otp, time.Now()); ok {
+		return repo.UseTotpStep(ctx, userID, step)
+	}
+
+	return repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(otp))
+}
+
+func generateRecoveryCode() (string, error) {
+	token, err := conv.GenerateRandomToken(8)
+	if err != nil {
+		return "", err
+	}
+
+	token = strings.ToLower(strings.NewReplacer("-", "x", "_", "y").Replace(token))
+	return token[:5] + "-" + token[5:10], nil
+}
+
+func hashRecoveryCode(recoveryCode string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(recoveryCode), "-", ""))
+	return conv.HashToken(normalized)
+}
+
+func NewTwoFactorService(twoFactorRepo repository.TwoFactorRepository, userRepo repository.UserRepository, cfg *config.Config) TwoFactorService {
+	return &twoFactorService{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		cfg:           cfg,
+	}
+}
@@ -12,10 +12,18 @@ import (
 type UserService interface {
 	UpdatePassword(ctx context.Context, newPass string, id int64) error
 	GetUserByID(ctx context.Context, id int64) (*entity.UserEntity, error)
+
+	GetUsers(ctx context.Context, query entity.QueryString) ([]entity.UserEntity, int64, int64, error)
+	CreateUser(ctx context.Context, req entity.UserEntity) (int64, error)
+	UpdateUser(ctx context.Context, req entity.UserEntity) error
+	DeactivateUser(ctx context.Context, id int64, actorID int64) error
+	ReactivateUser(ctx context.Context, id int64) error
 }
 
 type userService struct {
 	userRepo repository.UserRepository
+	auditService AuditService
+	searchService SearchService
 }
 
 // GetUserByID implements UserService.
@@ -46,9 +54,151 @@ func (u *userService) UpdatePassword(ctx context.Context, newPass string, id int
 		return err
 	}
 
+	// Hashes never go into the audit log, only the fact that it changed.
+	u.auditService.Record(ctx, entity.AuditActionUpdate, entity.AuditEntityUser, id,
+		map[string]interface{}{"password": "[redacted]"},
+		map[string]interface{}{"password": "[changed]"})
+
+	return nil
+}
+
+// GetUsers implements UserService.
+func (u *userService) GetUsers(ctx context.Context, query entity.QueryString) ([]entity.UserEntity, int64, int64, error) {
+	results, totalData, totalPages, err := u.userRepo.GetUsers(ctx, query)
+	if err != nil {
+		code := "[SERVICE] GetUsers - 1"
+		log.Errorw(code, err)
+		return nil, 0, 0, err
+	}
+
+	return results, totalData, totalPages, nil
+}
+
+// CreateUser implements UserService.
+func (u *userService) CreateUser(ctx context.Context, req entity.UserEntity) (int64, error) {
+	password, err := conv.HashPassword(req.Password)
+	if err != nil {
+		code := "[SERVICE] CreateUser - 1"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	req.Password = password
+	id, err := u.userRepo.CreateUser(ctx, req)
+	if err != nil {
+		code := "[SERVICE] CreateUser - 2"
+		log.Errorw(code, err)
+		return 0, err
+	}
+
+	u.recordUserChange(ctx, entity.AuditActionCreate, id, nil)
+
+	return id, nil
+}
+
+// UpdateUser implements UserService.
+func (u *userService) UpdateUser(ctx context.Context, req entity.UserEntity) error {
+	current, err := u.userRepo.GetUserByID(ctx, req.ID)
+	if err != nil {
+		code := "[SERVICE] UpdateUser - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = u.userRepo.UpdateUser(ctx, req)
+	if err != nil {
+		code := "[SERVICE] UpdateUser - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	u.recordUserChange(ctx, entity.AuditActionUpdate, req.ID, current)
+	if req.Name != current.Name {
+		u.searchService.IndexContents(ctx, entity.QueryString{AuthorID: req.ID})
+	}
+
+	return nil
+}
+
+// DeactivateUser implements UserService.
+func (u *userService) DeactivateUser(ctx context.Context, id int64, actorID int64) error {
+	if id == actorID {
+		code := "[SERVICE] DeactivateUser - 1"
+		log.Errorw(code, ErrSelfDeactivation)
+		return ErrSelfDeactivation
+	}
+
+	current, err := u.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		code := "[SERVICE] DeactivateUser - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = u.userRepo.SetUserActive(ctx, id, false)
+	if err != nil {
+		code := "[SERVICE] DeactivateUser - 3"
+		log.Errorw(code, err)
+		return err
+	}
+
+	u.recordUserChange(ctx, entity.AuditActionUpdate, id, current)
+
+	return nil
+}
+
+// ReactivateUser implements UserService.
+func (u *userService) ReactivateUser(ctx context.Context, id int64) error {
+	current, err := u.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		code := "[SERVICE] ReactivateUser - 1"
+		log.Errorw(code, err)
+		return err
+	}
+
+	err = u.userRepo.SetUserActive(ctx, id, true)
+	if err != nil {
+		code := "[SERVICE] ReactivateUser - 2"
+		log.Errorw(code, err)
+		return err
+	}
+
+	u.recordUserChange(ctx, entity.AuditActionUpdate, id, current)
+
 	return nil
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+// recordUserChange audits a user mutation, before is nil for a new user. The
+// after state is read back so the log shows what was actually stored.
+func (u *userService) recordUserChange(ctx context.Context, action string, id int64, before *entity.UserEntity) {
+	after, err := u.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		code := "[SERVICE] recordUserChange - 1"
+		log.Errorw(code, err)
+		return
+	}
+
+	var beforeSnapshot map[string]interface{}
+	if before != nil {
+		beforeSnapshot = userAuditSnapshot(*before)
+	}
+
+	u.auditService.Record(ctx, action, entity.AuditEntityUser, id, beforeSnapshot, userAuditSnapshot(*after))
+}
+
+func userAuditSnapshot(user entity.UserEntity) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      user.Name,
+		"email":     user.Email,
+		"role":      user.Role,
+		"is_active": user.IsActive,
+	}
+}
+
+func NewUserService(userRepo repository.UserRepository, auditService AuditService, searchService SearchService) UserService {
+	return &userService{
+		userRepo: userRepo,
+		auditService: auditService,
+		searchService: searchService,
+	}
 }
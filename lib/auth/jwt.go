@@ -3,69 +3,160 @@ package auth
 import (
 	"trustnews/config"
 	"trustnews/internal/core/domain/entity"
+	"crypto"
+	"sort"
 	"time"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 )
 
 type  Jwt interface {
 	GenerateToken(data *entity.JwtData)(string, int64, error)
 	VerifyAccessToken(token string)(*entity.JwtData, error)
+	Jwks() []entity.JwkEntity
 }
 
+// Options holds either a shared HS256 secret or, for RS256 and EdDSA, a private
+// signing key plus every public key (by kid) that is still accepted. Keeping the
+// previous public keys around lets tokens signed before a rotation stay valid.
 type Options struct {
 	signingKey string
 	issuer string
+	accessTokenTTL time.Duration
+
+	method jwt.SigningMethod
+	keyID string
+	privateKey crypto.Signer
+	verifyKeys map[string]crypto.PublicKey
 }
 
 func (o *Options) GenerateToken(data *entity.JwtData) (string, int64, error) {
 	now := time.Now().Local()
-	expiresAt := now.Add(time.Hour * 24)
+	expiresAt := now.Add(o.accessTokenTTL)
 	data.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	data.RegisteredClaims.IssuedAt = jwt.NewNumericDate(now)
 	data.RegisteredClaims.Issuer = o.issuer
 	data.RegisteredClaims.NotBefore = jwt.NewNumericDate(now)
-	acToken := jwt.NewWithClaims(jwt.SigningMethodHS256, data)
-	accessToken, err := acToken.SignedString([]byte(o.signingKey))
+	acToken := jwt.NewWithClaims(o.method, data)
+	if o.keyID != "" {
+		acToken.Header["kid"] = o.keyID
+	}
+
+	var signingKey interface{} = []byte(o.signingKey)
+	if o.privateKey != nil {
+		signingKey = o.privateKey
+	}
+
+	accessToken, err := acToken.SignedString(signingKey)
 	if err != nil {
 		return "", 0, err
-	} 
+	}
 
 	return accessToken, expiresAt.Unix(), nil
 }
 
 func (o *Options) VerifyAccessToken(token string) (*entity.JwtData, error) {
-	parsedToken, err := jwt.Parse(token, func(t *jwt.Token)(interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Signing method invalid")
-		}
-
-		return []byte(o.signingKey), nil
-	})
+	claims := &entity.JwtData{}
+	parsedToken, err := jwt.ParseWithClaims(token, claims, o.keyFunc,
+		jwt.WithValidMethods([]string{o.method.Alg()}),
+		jwt.WithIssuer(o.issuer),
+		jwt.WithExpirationRequired(),
+	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if parsedToken.Valid {
-		claim, ok := parsedToken.Claims.(jwt.MapClaims)
-		if !ok || !parsedToken.Valid {
-			return nil, err
-		}
+	if !parsedToken.Valid {
+		return nil, fmt.Errorf("Token is not valid")
+	}
+
+	return claims, nil
+}
 
-		jwtData := &entity.JwtData {
-			UserID: claim["user_id"].(float64),
+// Jwks returns the public verification keys, it is empty when tokens are
+// signed with a shared secret.
+func (o *Options) Jwks() []entity.JwkEntity {
+	kids := make([]string, 0, len(o.verifyKeys))
+	for kid := range o.verifyKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	keys := []entity.JwkEntity{}
+	for _, kid := range kids {
+		keys = append(keys, toJwk(kid, o.method.Alg(), o.verifyKeys[kid]))
+	}
+
+	return keys
+}
+
+func (o *Options) keyFunc(t *jwt.Token) (interface{}, error) {
+	if o.privateKey == nil {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Signing method invalid")
 		}
 
-		return jwtData, nil
+		return []byte(o.signingKey), nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := o.verifyKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("Unknown key id %q", kid)
 	}
 
-	return nil, fmt.Errorf("Token is not valid")
+	return key, nil
 }
 
 func NewJwt(cfg *config.Config) Jwt {
 	opt := new(Options)
 	opt.signingKey = cfg.App.JwtSecretKey
 	opt.issuer = cfg.App.JwtIssuer
+	opt.accessTokenTTL = cfg.App.JwtAccessTokenTTL
+	opt.method = jwt.SigningMethodHS256
+	opt.keyID = cfg.App.JwtKeyID
+	opt.verifyKeys = map[string]crypto.PublicKey{}
+
+	if cfg.App.JwtSigningMethod == "" || cfg.App.JwtSigningMethod == jwt.SigningMethodHS256.Alg() {
+		return opt
+	}
+
+	opt.method = jwt.GetSigningMethod(cfg.App.JwtSigningMethod)
+	if opt.method != jwt.SigningMethodRS256 && opt.method != jwt.SigningMethodEdDSA {
+		log.Fatal().Msgf("Unsupported JWT signing method %s, use HS256, RS256 or EdDSA", cfg.App.JwtSigningMethod)
+	}
+
+	if opt.keyID == "" {
+		log.Fatal().Msg("JWT_KEY_ID is required for asymmetric signing")
+	}
+
+	privateKey, err := loadPrivateKey(opt.method, cfg.App.JwtPrivateKeyPath)
+	if err != nil {
+		log.Fatal().Msgf("Unable to load JWT private key, %v", err)
+	}
+	opt.privateKey = privateKey
+	opt.verifyKeys[opt.keyID] = privateKey.Public()
+
+	extraKeys, err := parseKeyList(cfg.App.JwtVerifyKeys)
+	if err != nil {
+		log.Fatal().Msgf("Unable to parse JWT verification keys, %v", err)
+	}
+
+	for kid, path := range extraKeys {
+		if kid == opt.keyID {
+			continue
+		}
+
+		publicKey, err := loadPublicKey(opt.method, path)
+		if err != nil {
+			log.Fatal().Msgf("Unable to load JWT verification key %s, %v", kid, err)
+		}
+		opt.verifyKeys[kid] = publicKey
+	}
+
+	log.Info().Msgf("Loaded %d JWT verification keys, signing with %s", len(opt.verifyKeys), opt.keyID)
 
 	return opt
-}
\ No newline at end of file
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"trustnews/internal/core/domain/entity"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// loadPrivateKey reads the PEM encoded signing key used by the given method.
+func loadPrivateKey(method jwt.SigningMethod, path string) (crypto.Signer, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case jwt.SigningMethodRS256:
+		return jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+	case jwt.SigningMethodEdDSA:
+		key, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+		return key.(ed25519.PrivateKey), nil
+	}
+
+	return nil, fmt.Errorf("unsupported signing method %s", method.Alg())
+}
+
+func loadPublicKey(method jwt.SigningMethod, path string) (crypto.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case jwt.SigningMethodRS256:
+		return jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+	case jwt.SigningMethodEdDSA:
+		return jwt.ParseEdPublicKeyFromPEM(pemBytes)
+	}
+
+	return nil, fmt.Errorf("unsupported signing method %s", method.Alg())
+}
+
+// parseKeyList parses "kid=path,kid=path" into a map of kid to file path.
+func parseKeyList(raw string) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kid, path, found := strings.Cut(item, "=")
+		if !found || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid verification key entry %q, expected kid=path", item)
+		}
+
+		keys[strings.TrimSpace(kid)] = strings.TrimSpace(path)
+	}
+
+	return keys, nil
+}
+
+func toJwk(kid string, alg string, key crypto.PublicKey) entity.JwkEntity {
+	jwk := entity.JwkEntity{
+		Kid: kid,
+		Alg: alg,
+		Use: "sig",
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	}
+
+	return jwk
+}
@@ -1,6 +1,11 @@
 package conv
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -17,11 +22,50 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func GenerateSlug(title string) string {
-	slug := strings.ToLower(title)
-	slug = strings.ReplaceAll(slug, " ", "-")
+// GenerateRandomToken returns a url-safe random string built from n random bytes.
+func GenerateRandomToken(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken is used for random tokens that are stored server side. They carry
+// enough entropy on their own, so a fast digest is enough here, unlike passwords.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SplitTags splits a comma separated tag list, blank entries are dropped so an
+// empty list gives no tags instead of one empty tag.
+func SplitTags(raw string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(raw, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// UrlDomain returns the lower-cased host of a url without port and leading
+// "www.", a bare domain such as "example.com" is accepted as well.
+func UrlDomain(rawUrl string) string {
+	rawUrl = strings.TrimSpace(rawUrl)
+	if !strings.Contains(rawUrl, "://") {
+		rawUrl = "http://" + rawUrl
+	}
+
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
 
-	return slug
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
 }
 
 func StringToInt64(s string)(int64, error) {
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Change is the value of a single field before and after a mutation.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Compare returns the fields whose values differ between the two snapshots.
+// A nil snapshot stands for "did not exist", so creating or deleting a record
+// lists every field.
+func Compare(before, after map[string]interface{}) map[string]Change {
+	changes := map[string]Change{}
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok || !equal(oldValue, newValue) {
+			changes[key] = Change{Before: oldValue, After: newValue}
+		}
+	}
+
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = Change{Before: nil, After: newValue}
+		}
+	}
+
+	return changes
+}
+
+// Keys returns the changed field names in a stable order.
+func Keys(changes map[string]Change) []string {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// equal compares through JSON so values that only differ in their Go type,
+// like int64(1) and float64(1) from a decoded snapshot, count as equal.
+func equal(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return string(rawA) == string(rawB)
+}
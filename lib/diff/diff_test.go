@@ -0,0 +1,109 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"id":1,"tags":["a","b"],"title":"Old"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		before map[string]interface{}
+		after  map[string]interface{}
+		want   map[string]Change
+	}{
+		{
+			name:   "created",
+			before: nil,
+			after:  map[string]interface{}{"title": "New", "status": "DRAFT"},
+			want: map[string]Change{
+				"title":  {Before: nil, After: "New"},
+				"status": {Before: nil, After: "DRAFT"},
+			},
+		},
+		{
+			name:   "deleted",
+			before: map[string]interface{}{"title": "Old"},
+			after:  nil,
+			want:   map[string]Change{"title": {Before: "Old", After: nil}},
+		},
+		{
+			name:   "both nil",
+			before: nil,
+			after:  nil,
+			want:   map[string]Change{},
+		},
+		{
+			name:   "unchanged",
+			before: map[string]interface{}{"title": "Same", "count": 3},
+			after:  map[string]interface{}{"title": "Same", "count": 3},
+			want:   map[string]Change{},
+		},
+		{
+			name:   "changed field only",
+			before: map[string]interface{}{"title": "Old", "status": "DRAFT"},
+			after:  map[string]interface{}{"title": "New", "status": "DRAFT"},
+			want:   map[string]Change{"title": {Before: "Old", After: "New"}},
+		},
+		{
+			name:   "field removed and added",
+			before: map[string]interface{}{"excerpt": "Gone"},
+			after:  map[string]interface{}{"summary": "Here"},
+			want: map[string]Change{
+				"excerpt": {Before: "Gone", After: nil},
+				"summary": {Before: nil, After: "Here"},
+			},
+		},
+		{
+			name:   "decoded snapshot equals typed one",
+			before: decoded,
+			after:  map[string]interface{}{"id": int64(1), "tags": []string{"a", "b"}, "title": "Old"},
+			want:   map[string]Change{},
+		},
+		{
+			name:   "decoded snapshot differs in a slice",
+			before: decoded,
+			after:  map[string]interface{}{"id": int64(1), "tags": []string{"a"}, "title": "Old"},
+			want:   map[string]Change{"tags": {Before: []interface{}{"a", "b"}, After: []string{"a"}}},
+		},
+		{
+			name:   "nil values equal",
+			before: map[string]interface{}{"publish_at": nil},
+			after:  map[string]interface{}{"publish_at": nil},
+			want:   map[string]Change{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.before, tt.after)
+			if !reflect.DeepEqual(Keys(got), Keys(tt.want)) {
+				t.Fatalf("Compare changed %v, want %v", Keys(got), Keys(tt.want))
+			}
+
+			for key, change := range tt.want {
+				if !reflect.DeepEqual(got[key], change) {
+					t.Errorf("Compare[%q] = %#v, want %#v", key, got[key], change)
+				}
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	changes := map[string]Change{"title": {}, "body": {}, "status": {}, "author_id": {}}
+	want := []string{"author_id", "body", "status", "title"}
+	if got := Keys(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+
+	if got := Keys(map[string]Change{}); len(got) != 0 {
+		t.Errorf("Keys of no changes = %v", got)
+	}
+}
@@ -1,49 +1,259 @@
 package middleware
 
 import (
-	"trustnews/config"
+	"trustnews/internal/adapter/repository"
 	"trustnews/internal/adapter/handler/response"
+	"trustnews/internal/core/domain/entity"
 	"trustnews/lib/auth"
+	"trustnews/lib/conv"
+	"errors"
+	"slices"
 	"strings"
+	"time"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cache"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
 )
 
+const apiKeyHeader = "X-API-Key"
+
 type Middleware interface {
 	CheckToken() fiber.Handler
+	CheckSetupToken() fiber.Handler
+	RequireRole(roles ...string) fiber.Handler
+	RequireAccess(scope string, roles ...string) fiber.Handler
 }
 
 type Options struct {
 	authJwt auth.Jwt
+	authRepo repository.AuthRepository
+	apiKeyRepo repository.ApiKeyRepository
 }
 
+// CheckToken accepts a Bearer access token or an X-API-Key header and stores an
+// *entity.Principal in c.Locals("user").
 func (o *Options) CheckToken() func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		if apiKey := c.Get(apiKeyHeader); apiKey != "" {
+			return o.checkApiKey(c, apiKey)
+		}
+
+		return o.checkJwt(c, false)
+	}
+}
+
+// CheckSetupToken also accepts access tokens issued to users whose role requires
+// 2FA but who have not enrolled yet. It is only used on the enrollment routes
+// and logout, so those users cannot reach anything else. API keys are not
+// accepted here.
+func (o *Options) CheckSetupToken() func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		return o.checkJwt(c, true)
+	}
+}
+
+func (o *Options) checkApiKey(c *fiber.Ctx, apiKey string) error {
+	var errorResponse response.ErrorResponseDefault
+	key, err := o.apiKeyRepo.GetApiKeyByHash(c.Context(), conv.HashToken(apiKey))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResponse)
+	}
+
+	if key == nil || key.RevokedAt != nil || (key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt)) || !key.User.IsActive {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Invalid API Key"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	if err := o.apiKeyRepo.TouchApiKey(c.Context(), key.ID); err != nil {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResponse)
+	}
+
+	c.Locals("user", &entity.Principal{
+		Kind: entity.PrincipalApiKey,
+		UserID: key.UserID,
+		Role: key.User.Role,
+		ApiKeyID: key.ID,
+		Scopes: key.Scopes,
+	})
+
+	return c.Next()
+}
+
+func (o *Options) checkJwt(c *fiber.Ctx, allowSetup bool) error {
+	var errorResponse response.ErrorResponseDefault
+	authHandler :=  c.Get("Authorization")
+	if authHandler == "" {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Missing Authorization Header"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	tokenString, found := strings.CutPrefix(authHandler, "Bearer ")
+	if !found {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Invalid Authorization Header"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	claims, err := o.authJwt.VerifyAccessToken(tokenString)
+	if err != nil {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Invalid Token"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	revoked, err := o.authRepo.IsAccessTokenRevoked(c.Context(), claims.ID)
+	if err != nil {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = err.Error()
+		return c.Status(fiber.StatusInternalServerError).JSON(errorResponse)
+	}
+
+	if revoked {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Token Has Been Revoked"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	user, err := o.authRepo.GetUserByID(c.Context(), int64(claims.UserID))
+	if err != nil || !user.IsActive {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "User Account Is Not Active"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	// a password reset ends every session, the JWT only carries whole seconds
+	if user.SessionsRevokedAt != nil && claims.IssuedAt != nil && claims.IssuedAt.Time.Before(user.SessionsRevokedAt.Truncate(time.Second)) {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Token Has Been Revoked"
+		return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+	}
+
+	if claims.MfaSetupRequired && !allowSetup && !user.TwoFactorEnabled {
+		errorResponse.Meta.Status = false
+		errorResponse.Meta.Message = "Two-Factor Authentication Setup Required"
+		return c.Status(fiber.StatusForbidden).JSON(errorResponse)
+	}
+
+	// Role changes made by an admin apply right away instead of on the next login.
+	claims.Role = user.Role
+
+	c.Locals("user", &entity.Principal{
+		Kind: entity.PrincipalUser,
+		UserID: int64(claims.UserID),
+		Role: user.Role,
+		Claims: claims,
+	})
+
+	return c.Next()
+}
+
+// RequireRole must run after CheckToken. It only lets the request through when
+// the caller is a user whose role is one of the given roles, API keys are
+// always rejected.
+func (o *Options) RequireRole(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var errorResponse response.ErrorResponseDefault
-		authHandler :=  c.Get("Authorization")
-		if authHandler == "" {
+		principal, ok := c.Locals("user").(*entity.Principal)
+		if !ok || principal.IsApiKey() || !slices.Contains(roles, principal.Role) {
 			errorResponse.Meta.Status = false
-			errorResponse.Meta.Message = "Missing Authorization Header"
-			return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+			errorResponse.Meta.Message = "Forbidden Access"
+			return c.Status(fiber.StatusForbidden).JSON(errorResponse)
 		}
 
-		tokenString := strings.Split(authHandler, "Bearer ")[1]
-		claims, err := o.authJwt.VerifyAccessToken(tokenString)
-		if err != nil {
-			errorResponse.Meta.Status = false
-			errorResponse.Meta.Message = "Invalid Token"
-			return c.Status(fiber.StatusUnauthorized).JSON(errorResponse)
+		return c.Next()
+	}
+}
+
+// RequireAccess must run after CheckToken. Users and the owners of API keys
+// need one of the roles, or any role when none are given, API keys need the
+// scope as well.
+func (o *Options) RequireAccess(scope string, roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var errorResponse response.ErrorResponseDefault
+		principal, ok := c.Locals("user").(*entity.Principal)
+
+		allowed := ok
+		if ok && principal.IsApiKey() {
+			allowed = principal.HasScope(scope)
+		}
+		if allowed && len(roles) > 0 {
+			allowed = slices.Contains(roles, principal.Role)
 		}
 
-		c.Locals("user", claims)
+		if !allowed {
+			errorResponse.Meta.Status = false
+			errorResponse.Meta.Message = "Forbidden Access"
+			return c.Status(fiber.StatusForbidden).JSON(errorResponse)
+		}
 
 		return c.Next()
 	}
-	panic("unimplemented")
 }
 
-func NewMiddleware(cfg *config.Config) Middleware{
+func NewMiddleware(authJwt auth.Jwt, authRepo repository.AuthRepository, apiKeyRepo repository.ApiKeyRepository) Middleware{
 	opt := new(Options)
-	opt.authJwt = auth.NewJwt(cfg)
+	opt.authJwt = authJwt
+	opt.authRepo = authRepo
+	opt.apiKeyRepo = apiKeyRepo
 
 	return opt
-}
\ No newline at end of file
+}
+
+// RequestMeta stores the client IP and user agent in c.Locals so services can
+// read them from the request context, e.g. for the audit log.
+func RequestMeta() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(entity.RequestMetaKey, entity.RequestMeta{
+			IPAddress: strings.Clone(c.IP()),
+			UserAgent: strings.Clone(c.Get(fiber.HeaderUserAgent)),
+		})
+
+		return c.Next()
+	}
+}
+
+// RateLimit allows max requests per client IP within window and answers 429
+// once the limit is reached. A max of 0 disables the limit.
+func RateLimit(max int, window time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Next: func(c *fiber.Ctx) bool {
+			return max <= 0
+		},
+		Max: max,
+		Expiration: window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			var errorResponse response.ErrorResponseDefault
+			errorResponse.Meta.Status = false
+			errorResponse.Meta.Message = "Too Many Requests, Try Again Later"
+			return c.Status(fiber.StatusTooManyRequests).JSON(errorResponse)
+		},
+	})
+}
+
+// Cache keeps successful responses in memory for expiration, keyed by path and
+// query string, and lets clients cache them as long. An expiration of 0
+// disables it.
+func Cache(expiration time.Duration) fiber.Handler {
+	return cache.New(cache.Config{
+		Next: func(c *fiber.Ctx) bool {
+			return expiration <= 0 || c.Response().StatusCode() != fiber.StatusOK
+		},
+		Expiration: expiration,
+		CacheControl: true,
+		MaxBytes: 4 << 20,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return utils.CopyString(c.OriginalURL())
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Kind is the type of one sort value of a cursor.
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindInt
+	KindFloat
+	KindTime
+)
+
+// Cursor is the position of a keyset page: the sort it was taken with and the
+// sort values of the row the page starts after, or ends before when Backward
+// is set. Clients get it as signed base64 encoded JSON and pass it back
+// untouched.
+type Cursor struct {
+	Sort     string        `json:"sort"`
+	Values   []interface{} `json:"values"`
+	Backward bool          `json:"backward,omitempty"`
+}
+
+// Encode returns the cursor signed with secret, so a cursor changed by the
+// client is refused by DecodeCursor.
+func (c Cursor) Encode(secret []byte) string {
+	raw, _ := json.Marshal(c)
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload, secret))
+}
+
+// DecodeCursor reads a cursor made by Encode with the same secret. It needs
+// the kind of each value, strings come back as string, whole numbers as
+// int64, other numbers as float64 and times in RFC 3339 as time.Time.
+func DecodeCursor(encoded string, secret []byte, kinds []Kind) (*Cursor, error) {
+	payload, signature, found := strings.Cut(encoded, ".")
+	if !found {
+		return nil, ErrorCursorInvalid
+	}
+
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sum, signCursor(payload, secret)) {
+		return nil, ErrorCursorInvalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrorCursorInvalid
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var cursor Cursor
+	if err := decoder.Decode(&cursor); err != nil || len(cursor.Values) != len(kinds) {
+		return nil, ErrorCursorInvalid
+	}
+
+	for i, kind := range kinds {
+		value, ok := cursorValue(cursor.Values[i], kind)
+		if !ok {
+			return nil, ErrorCursorInvalid
+		}
+		cursor.Values[i] = value
+	}
+
+	return &cursor, nil
+}
+
+// cursorValue converts a decoded JSON value to kind.
+func cursorValue(value interface{}, kind Kind) (interface{}, bool) {
+	switch kind {
+	case KindInt:
+		if number, ok := value.(json.Number); ok {
+			n, err := number.Int64()
+			return n, err == nil
+		}
+	case KindFloat:
+		if number, ok := value.(json.Number); ok {
+			f, err := number.Float64()
+			return f, err == nil
+		}
+	case KindTime:
+		if text, ok := value.(string); ok {
+			t, err := time.Parse(time.RFC3339Nano, text)
+			return t, err == nil
+		}
+	case KindString:
+		text, ok := value.(string)
+		return text, ok
+	}
+
+	return nil, false
+}
+
+func signCursor(payload string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
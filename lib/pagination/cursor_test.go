@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("cursor-secret")
+
+func TestDecodeCursorRoundTrip(t *testing.T) {
+	published := time.Date(2024, 5, 17, 8, 30, 15, 123456789, time.UTC)
+	tests := []struct {
+		name   string
+		cursor Cursor
+		kinds  []Kind
+		want   []interface{}
+	}{
+		{
+			name:   "time and id",
+			cursor: Cursor{Sort: "-published_at", Values: []interface{}{published, int64(42)}},
+			kinds:  []Kind{KindTime, KindInt},
+			want:   []interface{}{published, int64(42)},
+		},
+		{
+			name:   "string and id backward",
+			cursor: Cursor{Sort: "title", Values: []interface{}{"Ünïcode \"quoted\"", int64(7)}, Backward: true},
+			kinds:  []Kind{KindString, KindInt},
+			want:   []interface{}{"Ünïcode \"quoted\"", int64(7)},
+		},
+		{
+			name:   "float rank",
+			cursor: Cursor{Sort: "-relevance", Values: []interface{}{0.0625, int64(3)}},
+			kinds:  []Kind{KindFloat, KindInt},
+			want:   []interface{}{0.0625, int64(3)},
+		},
+		{
+			name:   "whole number as float",
+			cursor: Cursor{Sort: "-relevance", Values: []interface{}{1, int64(3)}},
+			kinds:  []Kind{KindFloat, KindInt},
+			want:   []interface{}{float64(1), int64(3)},
+		},
+		{
+			name:   "large id",
+			cursor: Cursor{Sort: "id", Values: []interface{}{int64(1) << 60}},
+			kinds:  []Kind{KindInt},
+			want:   []interface{}{int64(1) << 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode(testSecret), testSecret, tt.kinds)
+			if err != nil {
+				t.Fatalf("DecodeCursor error = %v", err)
+			}
+			if got.Sort != tt.cursor.Sort || got.Backward != tt.cursor.Backward {
+				t.Errorf("DecodeCursor = %+v, want sort %q backward %v", got, tt.cursor.Sort, tt.cursor.Backward)
+			}
+			if !reflect.DeepEqual(got.Values, tt.want) {
+				t.Errorf("DecodeCursor values = %#v, want %#v", got.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	sign := func(payload string) string {
+		encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+		return encoded + "." + base64.RawURLEncoding.EncodeToString(signCursor(encoded, testSecret))
+	}
+	valid := Cursor{Sort: "-published_at", Values: []interface{}{"2024-05-17T08:30:15Z", int64(42)}}.Encode(testSecret)
+	payload, signature, _ := strings.Cut(valid, ".")
+
+	tests := []struct {
+		name    string
+		encoded string
+		secret  []byte
+		kinds   []Kind
+	}{
+		{name: "empty", encoded: "", kinds: []Kind{KindInt}},
+		{name: "unsigned", encoded: payload, kinds: []Kind{KindTime, KindInt}},
+		{name: "other secret", encoded: valid, secret: []byte("other"), kinds: []Kind{KindTime, KindInt}},
+		{name: "changed payload", encoded: base64.RawURLEncoding.EncodeToString([]byte(`{"sort":"id","values":[1]}`)) + "." + signature, kinds: []Kind{KindInt}},
+		{name: "bad signature encoding", encoded: payload + ".!!!", kinds: []Kind{KindTime, KindInt}},
+		{name: "bad payload encoding", encoded: "!!!." + base64.RawURLEncoding.EncodeToString(signCursor("!!!", testSecret)), kinds: []Kind{KindInt}},
+		{name: "not json", encoded: sign("not json"), kinds: []Kind{KindInt}},
+		{name: "fewer values than keys", encoded: sign(`{"sort":"id","values":[1]}`), kinds: []Kind{KindTime, KindInt}},
+		{name: "more values than keys", encoded: sign(`{"sort":"id","values":[1,2]}`), kinds: []Kind{KindInt}},
+		{name: "no values", encoded: sign(`{"sort":"id","values":[]}`), kinds: []Kind{KindInt}},
+		{name: "string for int", encoded: sign(`{"sort":"id","values":["1"]}`), kinds: []Kind{KindInt}},
+		{name: "fraction for int", encoded: sign(`{"sort":"id","values":[1.5]}`), kinds: []Kind{KindInt}},
+		{name: "string for float", encoded: sign(`{"sort":"id","values":["0.5"]}`), kinds: []Kind{KindFloat}},
+		{name: "number for string", encoded: sign(`{"sort":"id","values":[1]}`), kinds: []Kind{KindString}},
+		{name: "number for time", encoded: sign(`{"sort":"id","values":[1]}`), kinds: []Kind{KindTime}},
+		{name: "time not in RFC 3339", encoded: sign(`{"sort":"id","values":["17/05/2024"]}`), kinds: []Kind{KindTime}},
+		{name: "null value", encoded: sign(`{"sort":"id","values":[null]}`), kinds: []Kind{KindString}},
+		{name: "object value", encoded: sign(`{"sort":"id","values":[{"a":1}]}`), kinds: []Kind{KindString}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := tt.secret
+			if secret == nil {
+				secret = testSecret
+			}
+
+			got, err := DecodeCursor(tt.encoded, secret, tt.kinds)
+			if !errors.Is(err, ErrorCursorInvalid) {
+				t.Errorf("DecodeCursor = %+v, %v, want ErrorCursorInvalid", got, err)
+			}
+		})
+	}
+}
@@ -7,4 +7,5 @@ var(
 	ErrorPage 			= errors.New("page must greater than 0")
 	ErrorPageEmpty 		= errors.New("page cannot be empty")
 	ErrorPageInvalid 	= errors.New("page invalid, must be number")
+	ErrorCursorInvalid 	= errors.New("cursor invalid")
 )
\ No newline at end of file
@@ -0,0 +1,115 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations covers Latin letters that do not decompose into an ASCII
+// base letter plus combining marks.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe",
+	'ø': "o", 'Ø': "o", 'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d",
+	'þ': "th", 'Þ': "th", 'ł': "l", 'Ł': "l", 'ı': "i", 'ŋ': "n",
+	'Ŋ': "n", 'ħ': "h", 'Ħ': "h", 'ŧ': "t", 'Ŧ': "t", 'ĸ': "k",
+}
+
+// apostrophes are dropped without a separator, "don't" becomes "dont".
+var apostrophes = "'’ʼ`´"
+
+type Slugifier struct {
+	maxLength int
+	stopWords map[string]bool
+}
+
+// New returns a Slugifier cutting slugs at maxLength (0 means no limit) and
+// leaving out stopWords.
+func New(maxLength int, stopWords []string) *Slugifier {
+	words := map[string]bool{}
+	for _, word := range stopWords {
+		if word = strings.TrimSpace(strings.ToLower(word)); word != "" {
+			words[word] = true
+		}
+	}
+
+	return &Slugifier{
+		maxLength: maxLength,
+		stopWords: words,
+	}
+}
+
+// Make turns text into a lower-case ASCII slug of words joined by single
+// dashes. It returns an empty string when nothing usable is left.
+func (s *Slugifier) Make(text string) string {
+	words := s.removeStopWords(split(text))
+
+	var slug strings.Builder
+	for _, word := range words {
+		if s.maxLength > 0 && slug.Len() > 0 && slug.Len()+1+len(word) > s.maxLength {
+			break
+		}
+
+		if slug.Len() > 0 {
+			slug.WriteByte('-')
+		}
+		slug.WriteString(word)
+	}
+
+	// a single word longer than the limit has no boundary to cut at
+	if s.maxLength > 0 && slug.Len() > s.maxLength {
+		return slug.String()[:s.maxLength]
+	}
+
+	return slug.String()
+}
+
+// split decomposes text, transliterates it to ASCII and returns the runs of
+// letters and digits.
+func split(text string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range norm.NFKD.String(text) {
+		switch {
+		case transliterations[r] != "":
+			word.WriteString(transliterations[r])
+		case unicode.Is(unicode.Mn, r), strings.ContainsRune(apostrophes, r):
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return words
+}
+
+// removeStopWords keeps the words when all of them are stop words, a title
+// like "The Who" still needs a slug.
+func (s *Slugifier) removeStopWords(words []string) []string {
+	if len(s.stopWords) == 0 {
+		return words
+	}
+
+	kept := []string{}
+	for _, word := range words {
+		if !s.stopWords[word] {
+			kept = append(kept, word)
+		}
+	}
+
+	if len(kept) == 0 {
+		return words
+	}
+
+	return kept
+}
@@ -0,0 +1,43 @@
+package slug
+
+import "testing"
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength int
+		stopWords []string
+		text      string
+		want      string
+	}{
+		{name: "plain words", text: "Hello World", want: "hello-world"},
+		{name: "diacritics", text: "Café Crème Brûlée", want: "cafe-creme-brulee"},
+		{name: "transliterated letters", text: "Straße Øresund Łódź", want: "strasse-oresund-lodz"},
+		{name: "compatibility ligature", text: "ﬁnance", want: "finance"},
+		{name: "apostrophes joined", text: "Don't Stop’em", want: "dont-stopem"},
+		{name: "emoji dropped", text: "Hello 👋 World 🌍", want: "hello-world"},
+		{name: "repeated dashes", text: "a -- b___c", want: "a-b-c"},
+		{name: "leading and trailing separators", text: "  --Breaking News--  ", want: "breaking-news"},
+		{name: "digits kept", text: "Pemilu 2024: Hasil", want: "pemilu-2024-hasil"},
+		{name: "nothing usable", text: "政治 !!!", want: ""},
+		{name: "empty", text: "", want: ""},
+		{name: "cut at word boundary", maxLength: 12, text: "the quick brown fox", want: "the-quick"},
+		{name: "boundary exactly at limit", maxLength: 15, text: "the quick brown fox", want: "the-quick-brown"},
+		{name: "no shorter word after the cut", maxLength: 5, text: "ab verylongword c", want: "ab"},
+		{name: "single word longer than limit", maxLength: 5, text: "Supercalifragilistic", want: "super"},
+		{name: "no limit", maxLength: 0, text: "one two three four five", want: "one-two-three-four-five"},
+		{name: "stop words left out", stopWords: []string{"the", " And ", "a"}, text: "The Cat and a Hat", want: "cat-hat"},
+		{name: "only stop words kept", stopWords: []string{"the", "who"}, text: "The Who", want: "the-who"},
+		{name: "blank stop words ignored", stopWords: []string{"", "  "}, text: "Hello World", want: "hello-world"},
+		{name: "stop words before the cut", maxLength: 10, stopWords: []string{"the"}, text: "The quick brown fox", want: "quick"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.maxLength, tt.stopWords).Make(tt.text)
+			if got != tt.want {
+				t.Errorf("Make(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
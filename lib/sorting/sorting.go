@@ -0,0 +1,156 @@
+package sorting
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Key is one key of a sort, a leading "-" in the query makes it descending.
+type Key struct {
+	Name string
+	Desc bool
+}
+
+func (k Key) String() string {
+	if k.Desc {
+		return "-" + k.Name
+	}
+
+	return k.Name
+}
+
+// InvalidKeyError is returned by Parse for a key the endpoint does not
+// declare, or one that is given twice.
+type InvalidKeyError struct {
+	Key     string
+	Allowed []string
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf("Invalid Sort Key %q, Allowed Values: %s (prefix - for descending)", e.Key, strings.Join(e.Allowed, ", "))
+}
+
+// Parse reads a comma separated sort like "-published_at,title". Every key has
+// to be one of allowed, an empty sort returns no keys.
+func Parse(raw string, allowed []string) ([]Key, error) {
+	keys := []Key{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := Key{Name: strings.TrimLeft(part, "+-"), Desc: strings.HasPrefix(part, "-")}
+		if !slices.Contains(allowed, key.Name) || slices.ContainsFunc(keys, func(k Key) bool { return k.Name == key.Name }) {
+			return nil, &InvalidKeyError{Key: part, Allowed: allowed}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// WithTiebreak appends name in the direction of the last key unless keys
+// already sort on it, so rows that tie on every key keep a stable order.
+func WithTiebreak(keys []Key, name string) []Key {
+	desc := false
+	for _, key := range keys {
+		if key.Name == name {
+			return keys
+		}
+		desc = key.Desc
+	}
+
+	return append(slices.Clone(keys), Key{Name: name, Desc: desc})
+}
+
+// OrderBy builds the ORDER BY clause of keys, columns maps every key to the
+// column or expression it sorts on. Keys without a column are left out, at
+// least one has to remain (WithTiebreak takes care of that).
+func OrderBy(keys []Key, columns map[string]clause.Expr) clause.OrderBy {
+	parts := []string{}
+	vars := []interface{}{}
+	for _, key := range keys {
+		column, ok := columns[key.Name]
+		if !ok {
+			continue
+		}
+
+		direction := " ASC"
+		if key.Desc {
+			direction = " DESC"
+		}
+
+		parts = append(parts, column.SQL+direction)
+		vars = append(vars, column.Vars...)
+	}
+
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(parts, ", "),
+		Vars:               vars,
+		WithoutParentheses: true,
+	}}
+}
+
+// Reverse flips the direction of every key, a page before a cursor is read in
+// reverse and turned around afterwards.
+func Reverse(keys []Key) []Key {
+	reversed := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		reversed = append(reversed, Key{Name: key.Name, Desc: !key.Desc})
+	}
+
+	return reversed
+}
+
+// Seek builds the condition of a keyset page, it matches the rows that come
+// after values in the order of keys. values holds one value per key, keys
+// should end with a unique one (WithTiebreak) so no row is matched twice.
+func Seek(keys []Key, columns map[string]clause.Expr, values []interface{}) clause.Expr {
+	ors := []string{}
+	vars := []interface{}{}
+	for i, key := range keys {
+		column, ok := columns[key.Name]
+		if !ok || i >= len(values) {
+			continue
+		}
+
+		// every key before this one ties, this one moves past the value
+		ands := []string{}
+		for j := range i {
+			previous, ok := columns[keys[j].Name]
+			if !ok {
+				continue
+			}
+			ands = append(ands, previous.SQL+" = ?")
+			vars = append(vars, previous.Vars...)
+			vars = append(vars, values[j])
+		}
+
+		operator := " > ?"
+		if key.Desc {
+			operator = " < ?"
+		}
+		ands = append(ands, column.SQL+operator)
+		vars = append(vars, column.Vars...)
+		vars = append(vars, values[i])
+
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(ors, " OR ") + ")", Vars: vars}
+}
+
+// Format is the reverse of Parse.
+func Format(keys []Key) string {
+	parts := []string{}
+	for _, key := range keys {
+		parts = append(parts, key.String())
+	}
+
+	return strings.Join(parts, ",")
+}
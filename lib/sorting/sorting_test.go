@@ -0,0 +1,167 @@
+package sorting
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm/clause"
+)
+
+func TestParse(t *testing.T) {
+	allowed := []string{"published_at", "title", "created_at"}
+	tests := []struct {
+		name       string
+		raw        string
+		want       []Key
+		invalidKey string
+	}{
+		{name: "empty", raw: "", want: []Key{}},
+		{name: "only commas", raw: " , ,", want: []Key{}},
+		{name: "single ascending", raw: "title", want: []Key{{Name: "title"}}},
+		{name: "explicit ascending", raw: "+title", want: []Key{{Name: "title"}}},
+		{name: "descending and ascending", raw: "-published_at,title", want: []Key{{Name: "published_at", Desc: true}, {Name: "title"}}},
+		{name: "spaces trimmed", raw: " -created_at , title ", want: []Key{{Name: "created_at", Desc: true}, {Name: "title"}}},
+		{name: "unknown key", raw: "title,-views", invalidKey: "-views"},
+		{name: "repeated key", raw: "title,-title", invalidKey: "-title"},
+		{name: "case sensitive", raw: "Title", invalidKey: "Title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw, allowed)
+			if tt.invalidKey != "" {
+				var keyErr *InvalidKeyError
+				if !errors.As(err, &keyErr) {
+					t.Fatalf("Parse(%q) error = %v, want an InvalidKeyError", tt.raw, err)
+				}
+				if keyErr.Key != tt.invalidKey || !reflect.DeepEqual(keyErr.Allowed, allowed) {
+					t.Errorf("Parse(%q) error = %+v, want key %q", tt.raw, keyErr, tt.invalidKey)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.raw, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+			if Format(got) != Format(tt.want) {
+				t.Errorf("Format(Parse(%q)) = %q", tt.raw, Format(got))
+			}
+		})
+	}
+}
+
+func TestWithTiebreak(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+		want []Key
+	}{
+		{name: "no keys", keys: []Key{}, want: []Key{{Name: "id"}}},
+		{name: "follows the last key", keys: []Key{{Name: "title"}, {Name: "created_at", Desc: true}}, want: []Key{{Name: "title"}, {Name: "created_at", Desc: true}, {Name: "id", Desc: true}}},
+		{name: "ascending last key", keys: []Key{{Name: "created_at", Desc: true}, {Name: "title"}}, want: []Key{{Name: "created_at", Desc: true}, {Name: "title"}, {Name: "id"}}},
+		{name: "already sorted on it", keys: []Key{{Name: "id", Desc: true}, {Name: "title"}}, want: []Key{{Name: "id", Desc: true}, {Name: "title"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := append(make([]Key, 0, len(tt.keys)+1), tt.keys...)
+			got := WithTiebreak(keys, "id")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithTiebreak(%v) = %v, want %v", tt.keys, got, tt.want)
+			}
+			if !reflect.DeepEqual(keys, tt.keys) {
+				t.Errorf("WithTiebreak changed its input to %v", keys)
+			}
+		})
+	}
+}
+
+func TestSeek(t *testing.T) {
+	columns := map[string]clause.Expr{
+		"created_at": {SQL: "created_at"},
+		"title":      {SQL: "title"},
+		"id":         {SQL: "id"},
+		"relevance":  {SQL: "ts_rank(v, q(?))", Vars: []interface{}{"news"}},
+	}
+	tests := []struct {
+		name     string
+		keys     []Key
+		values   []interface{}
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "single key",
+			keys:     []Key{{Name: "id"}},
+			values:   []interface{}{7},
+			wantSQL:  "((id > ?))",
+			wantVars: []interface{}{7},
+		},
+		{
+			name:     "descending with tiebreak",
+			keys:     []Key{{Name: "created_at", Desc: true}, {Name: "id", Desc: true}},
+			values:   []interface{}{"2024-01-02", 7},
+			wantSQL:  "((created_at < ?) OR (created_at = ? AND id < ?))",
+			wantVars: []interface{}{"2024-01-02", "2024-01-02", 7},
+		},
+		{
+			name:     "mixed directions",
+			keys:     []Key{{Name: "title"}, {Name: "created_at", Desc: true}, {Name: "id", Desc: true}},
+			values:   []interface{}{"b", "2024-01-02", 7},
+			wantSQL:  "((title > ?) OR (title = ? AND created_at < ?) OR (title = ? AND created_at = ? AND id < ?))",
+			wantVars: []interface{}{"b", "b", "2024-01-02", "b", "2024-01-02", 7},
+		},
+		{
+			name:     "expression with its own vars",
+			keys:     []Key{{Name: "relevance", Desc: true}, {Name: "id", Desc: true}},
+			values:   []interface{}{0.5, 7},
+			wantSQL:  "((ts_rank(v, q(?)) < ?) OR (ts_rank(v, q(?)) = ? AND id < ?))",
+			wantVars: []interface{}{"news", 0.5, "news", 0.5, 7},
+		},
+		{
+			name:     "key without a column skipped",
+			keys:     []Key{{Name: "unknown"}, {Name: "id"}},
+			values:   []interface{}{"x", 7},
+			wantSQL:  "((id > ?))",
+			wantVars: []interface{}{7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Seek(tt.keys, columns, tt.values)
+			if got.SQL != tt.wantSQL {
+				t.Errorf("Seek SQL = %q, want %q", got.SQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(got.Vars, tt.wantVars) {
+				t.Errorf("Seek vars = %v, want %v", got.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	columns := map[string]clause.Expr{
+		"title":     {SQL: "title"},
+		"id":        {SQL: "id"},
+		"relevance": {SQL: "ts_rank(v, q(?))", Vars: []interface{}{"news"}},
+	}
+
+	got := OrderBy([]Key{{Name: "relevance", Desc: true}, {Name: "unknown"}, {Name: "title"}, {Name: "id", Desc: true}}, columns)
+	expr := got.Expression.(clause.Expr)
+	if want := "ts_rank(v, q(?)) DESC, title ASC, id DESC"; expr.SQL != want {
+		t.Errorf("OrderBy SQL = %q, want %q", expr.SQL, want)
+	}
+	if want := []interface{}{"news"}; !reflect.DeepEqual(expr.Vars, want) {
+		t.Errorf("OrderBy vars = %v, want %v", expr.Vars, want)
+	}
+
+	reversed := Reverse([]Key{{Name: "title"}, {Name: "id", Desc: true}})
+	if want := []Key{{Name: "title", Desc: true}, {Name: "id"}}; !reflect.DeepEqual(reversed, want) {
+		t.Errorf("Reverse = %v, want %v", reversed, want)
+	}
+}
@@ -0,0 +1,109 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed of the RFC 6238 test vectors,
+// "12345678901234567890" in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC lists 8 digit codes, the last 6 digits are the 6 digit codes.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{unix: 59, code: "287082"},
+	{unix: 1111111109, code: "081804"},
+	{unix: 1111111111, code: "050471"},
+	{unix: 1234567890, code: "005924"},
+	{unix: 2000000000, code: "279037"},
+	{unix: 20000000000, code: "353130"},
+}
+
+func TestGenerateRFC6238(t *testing.T) {
+	key, err := encoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range rfc6238Vectors {
+		if got := generate(key, uint64(tt.unix/period)); got != tt.code {
+			t.Errorf("generate at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	step := at.Unix() / period
+	key, _ := encoding.DecodeString(rfc6238Secret)
+	previous := generate(key, uint64(step-1))
+	next := generate(key, uint64(step+1))
+	tooOld := generate(key, uint64(step-2))
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: rfc6238Secret, code: "005924", wantStep: step, wantOK: true},
+		{name: "previous step for drift", secret: rfc6238Secret, code: previous, wantStep: step - 1, wantOK: true},
+		{name: "next step for drift", secret: rfc6238Secret, code: next, wantStep: step + 1, wantOK: true},
+		{name: "two steps old", secret: rfc6238Secret, code: tooOld, wantOK: false},
+		{name: "spaces and lower case secret", secret: " " + strings.ToLower(rfc6238Secret) + " ", code: " 005924 ", wantStep: step, wantOK: true},
+		{name: "wrong code", secret: rfc6238Secret, code: "000000", wantOK: false},
+		{name: "too short", secret: rfc6238Secret, code: "05924", wantOK: false},
+		{name: "eight digits", secret: rfc6238Secret, code: "89005924", wantOK: false},
+		{name: "empty code", secret: rfc6238Secret, code: "", wantOK: false},
+		{name: "invalid secret", secret: "not base32!", code: "005924", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := Validate(tt.secret, tt.code, at)
+			if gotOK != tt.wantOK || (tt.wantOK && gotStep != tt.wantStep) {
+				t.Errorf("Validate(%q) = %d, %v, want %d, %v", tt.code, gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := GenerateSecret()
+
+	key, err := encoding.DecodeString(first)
+	if err != nil || len(key) != 20 {
+		t.Errorf("GenerateSecret = %q, want 20 bytes of unpadded base32", first)
+	}
+	if first == second {
+		t.Errorf("GenerateSecret returned %q twice", first)
+	}
+}
+
+func TestKeyURI(t *testing.T) {
+	uri, err := url.Parse(KeyURI("Trust News", "editor@trustnews.id", rfc6238Secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Trust News:editor@trustnews.id" {
+		t.Errorf("KeyURI = %s", uri)
+	}
+
+	want := map[string]string{"secret": rfc6238Secret, "issuer": "Trust News", "algorithm": "SHA1", "digits": "6", "period": "30"}
+	for key, value := range want {
+		if got := uri.Query().Get(key); got != value {
+			t.Errorf("KeyURI %s = %q, want %q", key, got, value)
+		}
+	}
+}
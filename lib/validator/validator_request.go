@@ -28,6 +28,8 @@ func ValidateStruct(s interface{}) error {
 					}
 				case "eqfield":
 					errorMessages = append(errorMessages, err.Field()+" harus sama dengan "+err.Param()+".")
+				case "excludes":
+					errorMessages = append(errorMessages, err.Field()+" tidak boleh mengandung \""+err.Param()+"\".")
 				default:
 					errorMessages = append(errorMessages, "Field "+err.Field()+" tidak valid.")
 			}